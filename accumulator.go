@@ -0,0 +1,77 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"runtime"
+	"sync"
+)
+
+// accShard is one partial sum of a ShardedAccumulator, padded to its own
+// cache line to avoid false sharing between shards.
+type accShard struct {
+	mu  sync.Mutex
+	sum Int
+	_   [24]byte
+}
+
+// ShardedAccumulator is a running total modulo m which may be updated from
+// many goroutines concurrently. Additions are spread over a number of
+// independently locked partial sums, which are folded together on Read.
+// The accumulator itself is never written after construction, so updates
+// to different shards share no cache lines.
+type ShardedAccumulator struct {
+	m      Int
+	shards []accShard
+}
+
+// NewShardedAccumulator returns an accumulator modulo m with the given
+// number of shards. If shards <= 0, runtime.GOMAXPROCS(0) shards are used.
+// If m == 0, the total is always 0 (OBS: differs from the big.Int)
+func NewShardedAccumulator(m *Int, shards int) *ShardedAccumulator {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	return &ShardedAccumulator{m: *m, shards: make([]accShard, shards)}
+}
+
+// Modulus returns the modulus of the accumulator.
+func (a *ShardedAccumulator) Modulus() *Int {
+	return a.m.Clone()
+}
+
+// AddMod adds x to the total, modulo m. It is safe for concurrent use.
+// The hint selects the partial sum that is updated, and is typically the
+// index of the calling worker: goroutines whose hints differ modulo the
+// number of shards never contend.
+func (a *ShardedAccumulator) AddMod(hint int, x *Int) {
+	s := &a.shards[uint(hint)%uint(len(a.shards))]
+	s.mu.Lock()
+	s.sum.AddMod(&s.sum, x, &a.m)
+	s.mu.Unlock()
+}
+
+// Read sets z to the current total modulo m, and returns z. Additions
+// running concurrently with Read may or may not be included.
+func (a *ShardedAccumulator) Read(z *Int) *Int {
+	var total Int
+	for i := range a.shards {
+		s := &a.shards[i]
+		s.mu.Lock()
+		total.AddMod(&total, &s.sum, &a.m)
+		s.mu.Unlock()
+	}
+	return z.Set(&total)
+}
+
+// Reset sets the total to 0.
+func (a *ShardedAccumulator) Reset() {
+	for i := range a.shards {
+		s := &a.shards[i]
+		s.mu.Lock()
+		s.sum.Clear()
+		s.mu.Unlock()
+	}
+}
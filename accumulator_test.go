@@ -0,0 +1,76 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShardedAccumulator(t *testing.T) {
+	const (
+		workers = 8
+		perWork = 500
+	)
+	for _, shards := range []int{0, 1, 3} {
+		bm, m, _ := randNums()
+		for m.IsZero() {
+			bm, m, _ = randNums()
+		}
+		var inputs [workers][perWork]Int
+		want := new(big.Int)
+		for i := range inputs {
+			for j := range inputs[i] {
+				b, f, err := randNums()
+				if err != nil {
+					t.Fatal(err)
+				}
+				inputs[i][j] = *f
+				want.Add(want, b)
+			}
+		}
+		want.Mod(want, bm)
+
+		acc := NewShardedAccumulator(m, shards)
+		var wg sync.WaitGroup
+		for i := range inputs {
+			wg.Add(1)
+			go func(hint int, xs *[perWork]Int) {
+				defer wg.Done()
+				for j := range xs {
+					acc.AddMod(hint, &xs[j])
+				}
+			}(i, &inputs[i])
+		}
+		wg.Wait()
+		requireEq(t, want, acc.Read(new(Int)), "ShardedAccumulator")
+
+		acc.Reset()
+		if got := acc.Read(new(Int)); !got.IsZero() {
+			t.Errorf("expected 0 after Reset, got %x", got)
+		}
+	}
+
+	acc := NewShardedAccumulator(new(Int), 2)
+	acc.AddMod(-1, NewInt(5))
+	if got := acc.Read(new(Int)); !got.IsZero() {
+		t.Errorf("expected 0 for zero modulus, got %x", got)
+	}
+}
+
+func BenchmarkShardedAccumulator(b *testing.B) {
+	m, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	acc := NewShardedAccumulator(m, 0)
+	var workers int32
+	b.RunParallel(func(pb *testing.PB) {
+		hint := int(atomic.AddInt32(&workers, 1))
+		for pb.Next() {
+			acc.AddMod(hint, x)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// chainStep squares the accumulator sqr times, then multiplies it by the
+// precomputed odd power x^(2*idx+1).
+type chainStep struct {
+	sqr uint16
+	idx uint8
+}
+
+// AddChain is an addition chain for a fixed exponent, suitable for
+// computing x^e mod m repeatedly for the same e, such as (p-3)/4 or (p+1)/4
+// for a prime p. The chain is built once using sliding windows over the
+// exponent, with the window size chosen to minimise the number of
+// multiplications.
+type AddChain struct {
+	exp   Int
+	odd   int // number of precomputed odd powers: x, x^3, ..., x^(2*odd-1)
+	first uint8
+	steps []chainStep
+}
+
+// NewAddChain returns an addition chain for the exponent e.
+func NewAddChain(e *Int) *AddChain {
+	c := &AddChain{exp: *e}
+	n := e.BitLen()
+	if n == 0 {
+		return c
+	}
+	// Pick the window size minimising table size plus window multiplications.
+	w, best := 1, n
+	for k := 2; k <= 6; k++ {
+		if cost := (1 << uint(k-1)) + n/(k+1); cost < best {
+			w, best = k, cost
+		}
+	}
+	c.odd = 1 << uint(w-1)
+
+	i, started := n-1, false
+	var sqr uint16
+	for i >= 0 {
+		if !e.isBitSet(uint(i)) {
+			sqr++
+			i--
+			continue
+		}
+		// Find the longest window e[i..l] of at most w bits ending in a set bit.
+		l := i - w + 1
+		if l < 0 {
+			l = 0
+		}
+		for !e.isBitSet(uint(l)) {
+			l++
+		}
+		var val uint
+		for j := i; j >= l; j-- {
+			val <<= 1
+			if e.isBitSet(uint(j)) {
+				val |= 1
+			}
+		}
+		if !started {
+			c.first = uint8(val >> 1)
+			started = true
+		} else {
+			c.steps = append(c.steps, chainStep{sqr: sqr + uint16(i-l+1), idx: uint8(val >> 1)})
+		}
+		sqr = 0
+		i = l - 1
+	}
+	if sqr > 0 {
+		c.steps = append(c.steps, chainStep{sqr: sqr, idx: 0xff})
+	}
+	return c
+}
+
+// Exponent returns the exponent the chain computes.
+func (c *AddChain) Exponent() *Int {
+	return c.exp.Clone()
+}
+
+// Len returns the number of modular multiplications (including squarings)
+// performed by Exp.
+func (c *AddChain) Len() int {
+	if c.odd == 0 {
+		return 0
+	}
+	n := 0
+	if c.odd > 1 {
+		n = c.odd // x^2 plus odd-1 table multiplications
+	}
+	for _, s := range c.steps {
+		n += int(s.sqr)
+		if s.idx != 0xff {
+			n++
+		}
+	}
+	return n
+}
+
+// Exp sets z to x**e mod m, where e is the exponent of the chain, and
+// returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (c *AddChain) Exp(z, x, m *Int) *Int {
+	if instrumented {
+		defer region("AddChain.Exp")()
+	}
+	if m.IsZero() {
+		return z.Clear()
+	}
+	var xr, one Int
+	xr.Mod(x, m)
+	one.Mod(&Int{1}, m)
+	return c.apply(z, &xr, &one, func(z, x, y *Int) {
+		z.MulMod(x, y, m)
+	})
+}
+
+// apply sets z to x**e, where e is the exponent of the chain, and returns z.
+// The arithmetic is that of mul, with one its identity; x must be in the
+// same representation.
+func (c *AddChain) apply(z, x, one *Int, mul func(z, x, y *Int)) *Int {
+	if c.odd == 0 {
+		return z.Set(one)
+	}
+	var table [32]Int
+	table[0] = *x
+	if c.odd > 1 {
+		var x2 Int
+		mul(&x2, &table[0], &table[0])
+		for i := 1; i < c.odd; i++ {
+			mul(&table[i], &table[i-1], &x2)
+		}
+	}
+	acc := table[c.first]
+	for _, s := range c.steps {
+		for j := uint16(0); j < s.sqr; j++ {
+			mul(&acc, &acc, &acc)
+		}
+		if s.idx != 0xff {
+			mul(&acc, &acc, &table[s.idx])
+		}
+	}
+	return z.Set(&acc)
+}
@@ -0,0 +1,66 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestAddChain(t *testing.T) {
+	p, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	exps := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(0x80),
+		new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(3)), 2),
+		new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2),
+		new(big.Int).Sub(p, big.NewInt(2)),
+		new(big.Int).Sub(bigtt256, big.NewInt(1)),
+	}
+	for i := 0; i < 20; i++ {
+		b, _, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		exps = append(exps, b)
+	}
+	mods := []*big.Int{big.NewInt(1), big.NewInt(7), p, new(big.Int).Sub(bigtt256, big.NewInt(1))}
+	for _, e := range exps {
+		ef, _ := FromBig(e)
+		c := NewAddChain(ef)
+		if !c.Exponent().Eq(ef) {
+			t.Fatalf("exponent mismatch: %x", e)
+		}
+		if l := c.Len(); l > 2*e.BitLen() {
+			t.Errorf("chain for %x too long: %d", e, l)
+		}
+		for _, m := range mods {
+			b, f, err := randNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			mf, _ := FromBig(m)
+			got := c.Exp(new(Int), f, mf)
+			requireEq(t, new(big.Int).Exp(b, e, m), got, "AddChain.Exp")
+		}
+	}
+	if got := NewAddChain(new(Int)).Exp(new(Int), NewInt(5), new(Int)); !got.IsZero() {
+		t.Errorf("expected 0 for zero modulus, got %x", got)
+	}
+}
+
+func BenchmarkAddChain(b *testing.B) {
+	p, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	e := new(Int).SubUint64(p, 3)
+	e.Rsh(e, 2)
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	c := NewAddChain(e)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Exp(new(Int), x, p)
+	}
+}
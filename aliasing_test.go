@@ -0,0 +1,193 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// partitions returns all ways of grouping n operands into aliasing classes,
+// as a slice mapping each operand to its class.
+func partitions(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var res [][]int
+	for _, p := range partitions(n - 1) {
+		classes := 0
+		for _, c := range p {
+			if c+1 > classes {
+				classes = c + 1
+			}
+		}
+		for c := 0; c <= classes; c++ {
+			res = append(res, append(append([]int{}, p...), c))
+		}
+	}
+	return res
+}
+
+// aliasingValues are interesting operand values; random ones are added by
+// the test.
+var aliasingValues = []Int{
+	{},
+	{1},
+	{2},
+	{0x8000000000000000, 0, 0, 0x8000000000000000},
+	{0, 0, 0, 0x8000000000000000},
+	{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)},
+	{^uint64(0) - 1, ^uint64(0), ^uint64(0), 0x7fffffffffffffff},
+	{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)},
+}
+
+// TestAliasing checks that every method of Int whose operands are *Int
+// gives the same results when the receiver and operands alias each other
+// in any combination, as when they are all distinct. Other integer
+// operands (shift amounts and the like) are held at fixed values.
+func TestAliasing(t *testing.T) {
+	intPtr := reflect.TypeOf(new(Int))
+	values := append([]Int{}, aliasingValues...)
+	for i := 0; i < 8; i++ {
+		_, f, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, *f)
+	}
+	for i := 0; i < intPtr.NumMethod(); i++ {
+		method := intPtr.Method(i)
+		mt := method.Type
+		var ptrs []int // indices of *Int parameters, including the receiver
+		supported := true
+		for j := 0; j < mt.NumIn(); j++ {
+			switch in := mt.In(j); {
+			case in == intPtr:
+				ptrs = append(ptrs, j)
+			case in.Kind() == reflect.Uint || in.Kind() == reflect.Uint64 || in.Kind() == reflect.Int:
+			default:
+				supported = false
+			}
+		}
+		if !supported || len(ptrs) < 2 {
+			continue
+		}
+		t.Run(method.Name, func(t *testing.T) {
+			checkAliasing(t, method.Func, ptrs, values)
+		})
+	}
+}
+
+func checkAliasing(t *testing.T, fn reflect.Value, ptrs []int, values []Int) {
+	mt := fn.Type()
+	// call invokes fn with the *Int operands set from ops and returns the
+	// final receiver value and the results, with *Int results dereferenced.
+	call := func(ops []*Int, scalar uint64) (Int, []interface{}) {
+		args := make([]reflect.Value, mt.NumIn())
+		k := 0
+		for j := range args {
+			if mt.In(j) == mt.In(0) {
+				args[j] = reflect.ValueOf(ops[k])
+				k++
+			} else {
+				args[j] = reflect.ValueOf(scalar).Convert(mt.In(j))
+			}
+		}
+		var out []interface{}
+		for _, r := range fn.Call(args) {
+			if r.Type() == mt.In(0) {
+				out = append(out, *r.Interface().(*Int))
+			} else {
+				out = append(out, r.Interface())
+			}
+		}
+		return *ops[0], out
+	}
+	for _, part := range partitions(len(ptrs)) {
+		for iter := 0; iter < 24; iter++ {
+			scalar := []uint64{0, 1, 3, 65, 200, 255, 256, 300}[iter%8]
+			// Each aliasing class gets one value.
+			classVals := make([]Int, len(ptrs))
+			for c := range classVals {
+				classVals[c] = values[(iter*7+c*5+len(part))%len(values)]
+			}
+			// Reference call with distinct operands holding the same values.
+			distinct := make([]*Int, len(ptrs))
+			for k, c := range part {
+				v := classVals[c]
+				distinct[k] = &v
+			}
+			wantZ, wantOut := call(distinct, scalar)
+
+			aliased := make([]*Int, len(ptrs))
+			shared := make([]*Int, len(ptrs))
+			for k, c := range part {
+				if shared[c] == nil {
+					v := classVals[c]
+					shared[c] = &v
+				}
+				aliased[k] = shared[c]
+			}
+			gotZ, gotOut := call(aliased, scalar)
+
+			if gotZ != wantZ || !reflect.DeepEqual(gotOut, wantOut) {
+				var in []string
+				for k, c := range part {
+					in = append(in, fmt.Sprintf("op%d(class %d)=%x", k, c, classVals[c]))
+				}
+				t.Fatalf("aliasing %v, scalar %d, inputs %v:\nreceiver %x, results %x\nwant receiver %x, results %x",
+					part, scalar, in, gotZ, gotOut, wantZ, wantOut)
+			}
+		}
+	}
+}
+
+// TestAliasingFuncs extends the aliasing matrix to unexported helpers and
+// to methods of other types operating on Int operands.
+func TestAliasingFuncs(t *testing.T) {
+	values := append([]Int{}, aliasingValues...)
+	for i := 0; i < 8; i++ {
+		_, f, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, *f)
+	}
+	p := Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	chain := NewAddChain(new(Int).SubUint64(&p, 2))
+	field := NewGF2Field(&Int{0x425})
+	modulus := NewModulus(&p)
+	for name, fn := range map[string]interface{}{
+		"lsh64":               (*Int).lsh64,
+		"rsh128":              (*Int).rsh128,
+		"srsh192":             (*Int).srsh192,
+		"AddChain.Exp":        chain.Exp,
+		"GF2Field.Mul":        field.Mul,
+		"GF2Field.Sqr":        field.Sqr,
+		"GF2Field.Inv":        field.Inv,
+		"Modulus.MulMod":      modulus.MulMod,
+		"Modulus.ExpMod":      modulus.ExpMod,
+		"Modulus.ExpModCT":    modulus.ExpModCT,
+		"Modulus.InvModPrime": modulus.InvModPrime,
+		"MulModWithReciprocal": func(z, x, y *Int) *Int {
+			mu := Reciprocal(&p)
+			return z.MulModWithReciprocal(x, y, &p, &mu)
+		},
+		"subModReduced": func(z, x, y *Int) *Int {
+			// The operands must be reduced; aliased ones have equal values,
+			// so reducing each one keeps the classes intact.
+			x.Mod(x, &p)
+			y.Mod(y, &p)
+			return z.subModReduced(x, y, &p)
+		},
+	} {
+		v := reflect.ValueOf(fn)
+		ptrs := make([]int, v.Type().NumIn())
+		t.Run(name, func(t *testing.T) {
+			checkAliasing(t, v, ptrs, values)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// The uint256vet command runs the uint256check analyzer. It can be used
+// standalone or through go vet:
+//
+//	go vet -vettool=$(which uint256vet) ./...
+package main
+
+import (
+	"github.com/holiman/uint256/analysis/uint256check"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(uint256check.Analyzer) }
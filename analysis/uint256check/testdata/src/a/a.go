@@ -0,0 +1,58 @@
+package a
+
+import "github.com/holiman/uint256"
+
+func discarded(x, y *uint256.Int) {
+	x.Eq(y)                            // want `result of \(\*uint256.Int\).Eq call is discarded`
+	x.Clone()                          // want `result of \(\*uint256.Int\).Clone call is discarded`
+	new(uint256.Int).Add(x, y)         // want `result of \(\*uint256.Int\).Add on a temporary receiver is discarded`
+	(&uint256.Int{}).Sub(x, y)         // want `result of \(\*uint256.Int\).Sub on a temporary receiver is discarded`
+	uint256.NewInt(1).Add(x, y)        // want `result of \(\*uint256.Int\).Add on a temporary receiver is discarded`
+	x.Clone().Add(x, y).Sub(x, y)      // want `result of \(\*uint256.Int\).Sub on a temporary receiver is discarded`
+	x.Add(x, y)                        // ok: updates x
+	new(uint256.Int).AddOverflow(x, y) // want `result of \(\*uint256.Int\).AddOverflow on a temporary receiver is discarded`
+	_ = new(uint256.Int).Add(x, y)     // ok: result used
+}
+
+func discardedDerived(x *uint256.Int, b []byte) {
+	x.Bytes32LE()         // want `result of \(\*uint256.Int\).Bytes32LE call is discarded`
+	x.Log2()              // want `result of \(\*uint256.Int\).Log2 call is discarded`
+	x.PaddedBytes(8)      // want `result of \(\*uint256.Int\).PaddedBytes call is discarded`
+	x.MarshalText()       // want `result of \(\*uint256.Int\).MarshalText call is discarded`
+	x.SetFromLiteral("1") // ok: sets x
+	x.UnmarshalText(b)    // ok: sets x
+	x.WriteToSlice(b)     // ok: writes b
+}
+
+func rangeCopy(xs []uint256.Int, y *uint256.Int) {
+	for _, v := range xs {
+		v.Add(&v, y) // want `v is a copy of the range element; \(\*uint256.Int\).Add does not modify the element`
+		_ = v.IsZero()
+	}
+	for i := range xs {
+		xs[i].Add(&xs[i], y) // ok: addresses the element
+	}
+	for _, p := range []*uint256.Int{y} {
+		p.SetUint64(1) // ok: pointer element
+	}
+}
+
+func derefCopy(p, y *uint256.Int) uint256.Int {
+	v := *p
+	v.Add(&v, y) // want `v is a copy of \*p; \(\*uint256.Int\).Add modifies the copy, which is never read`
+	v.Sub(&v, y)
+
+	w := *p
+	w.Add(&w, y) // ok: w is returned
+	if w.IsZero() {
+		return w
+	}
+
+	u := *p
+	u.Add(&u, y) // ok: u is read below
+	p.Add(p, &u)
+
+	t := *p
+	_ = t.Add(&t, y) // ok: the result is used
+	return uint256.Int{}
+}
@@ -0,0 +1,24 @@
+// Package uint256 is a minimal stand-in for the real package, used as
+// analyzer test input.
+package uint256
+
+type Int [4]uint64
+
+func NewInt(val uint64) *Int { return &Int{val} }
+
+func (z *Int) Add(x, y *Int) *Int                 { return z }
+func (z *Int) Sub(x, y *Int) *Int                 { return z }
+func (z *Int) SetUint64(x uint64) *Int            { return z }
+func (z *Int) Clone() *Int                        { return &Int{} }
+func (z *Int) Eq(x *Int) bool                     { return true }
+func (z *Int) Cmp(x *Int) int                     { return 0 }
+func (z *Int) IsZero() bool                       { return true }
+func (z *Int) Hex() string                        { return "" }
+func (z *Int) AddOverflow(x, y *Int) (*Int, bool) { return z, false }
+func (z *Int) Bytes32LE() [32]byte                { return [32]byte{} }
+func (z *Int) Log2() int                          { return 0 }
+func (z *Int) PaddedBytes(n int) []byte           { return nil }
+func (z *Int) MarshalText() ([]byte, error)       { return nil, nil }
+func (z *Int) SetFromLiteral(s string) error      { return nil }
+func (z *Int) UnmarshalText(input []byte) error   { return nil }
+func (z *Int) WriteToSlice(dest []byte)           {}
@@ -0,0 +1,289 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package uint256check defines an Analyzer that reports common misuses of
+// github.com/holiman/uint256.Int.
+//
+// The analyzer flags four patterns:
+//
+//   - calls to read-only methods (Eq, Cmp, Clone, Hex, ...) whose result
+//     is discarded, which are always no-ops;
+//   - calls to arithmetic methods on a temporary receiver such as
+//     new(uint256.Int).Add(x, y) whose result is discarded, which compute
+//     a value and then throw it away;
+//   - calls to mutating methods on a range value variable of type
+//     uint256.Int, which modify a copy instead of the slice element;
+//   - calls to mutating methods on a copy v := *p that is never read
+//     afterwards, which modify the copy instead of *p.
+//
+// Whether a method is read-only is derived from its signature, so methods
+// added to the package are classified without changes to the analyzer.
+package uint256check
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const pkgPath = "github.com/holiman/uint256"
+
+const doc = `check for common misuses of uint256.Int
+
+The uint256check analyzer reports discarded results of read-only Int
+methods, arithmetic on temporary receivers whose result is dropped, and
+mutations of range value copies and of unused dereferenced copies of Int.`
+
+// Analyzer is the uint256check analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:     "uint256check",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// mutatorPrefixes are name prefixes of methods which modify the receiver
+// even though their signature would allow them to be read-only, such as
+// SetFromBig(b *big.Int) bool or SetFromLiteral(s string) error.
+var mutatorPrefixes = []string{"Set", "Unmarshal", "Scan", "Decode"}
+
+// readOnly reports whether the Int method fn leaves its receiver and
+// arguments alone, so that a call whose result is discarded does nothing.
+// Such a method returns something other than the receiver, that is no
+// *Int other than the fresh copy of Clone, and takes only Ints and basic
+// values, which it cannot write to.
+func readOnly(fn *types.Func) bool {
+	for _, p := range mutatorPrefixes {
+		if strings.HasPrefix(fn.Name(), p) {
+			return false
+		}
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Results().Len() == 0 {
+		return false
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		if isIntPtr(sig.Results().At(i).Type()) && fn.Name() != "Clone" {
+			return false
+		}
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		t := sig.Params().At(i).Type()
+		if _, basic := t.Underlying().(*types.Basic); !basic && !isInt(t) && !isIntPtr(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.AssignStmt)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			checkDiscarded(pass, n)
+		case *ast.RangeStmt:
+			checkRangeCopy(pass, n)
+		case *ast.AssignStmt:
+			if body := enclosingBody(stack); body != nil {
+				checkDerefCopy(pass, n, body)
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkDiscarded reports statements whose only effect is lost.
+func checkDiscarded(pass *analysis.Pass, stmt *ast.ExprStmt) {
+	call, ok := stmt.X.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	sel, fn, ok := intMethod(pass, call)
+	if !ok {
+		return
+	}
+	name := fn.Name()
+	if readOnly(fn) {
+		pass.Reportf(call.Pos(), "result of (*uint256.Int).%s call is discarded", name)
+		return
+	}
+	if isTemporary(pass, sel.X) {
+		pass.Reportf(call.Pos(), "result of (*uint256.Int).%s on a temporary receiver is discarded", name)
+	}
+}
+
+// checkRangeCopy reports mutating method calls on a range value variable of
+// type Int, which only modify the loop's private copy.
+func checkRangeCopy(pass *analysis.Pass, rng *ast.RangeStmt) {
+	id, ok := rng.Value.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return
+	}
+	obj := pass.TypesInfo.ObjectOf(id)
+	if obj == nil || !isInt(obj.Type()) {
+		return
+	}
+	ast.Inspect(rng.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, fn, ok := intMethod(pass, call)
+		if !ok || readOnly(fn) {
+			return true
+		}
+		if recv, ok := sel.X.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(recv) == obj {
+			pass.Reportf(call.Pos(), "%s is a copy of the range element; (*uint256.Int).%s does not modify the element", id.Name, fn.Name())
+		}
+		return true
+	})
+}
+
+// enclosingBody returns the body of the innermost function in stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch f := stack[i].(type) {
+		case *ast.FuncDecl:
+			return f.Body
+		case *ast.FuncLit:
+			return f.Body
+		}
+	}
+	return nil
+}
+
+// checkDerefCopy reports mutating method calls on a variable defined as a
+// copy v := *p of an Int, if v is never read otherwise: the calls modify
+// the copy, and the result is lost.
+func checkDerefCopy(pass *analysis.Pass, assign *ast.AssignStmt, body *ast.BlockStmt) {
+	if assign.Tok != token.DEFINE || len(assign.Lhs) != len(assign.Rhs) {
+		return
+	}
+	for i, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok || id.Name == "_" {
+			continue
+		}
+		star, ok := ast.Unparen(assign.Rhs[i]).(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		obj := pass.TypesInfo.Defs[id]
+		if obj == nil || !isInt(obj.Type()) {
+			continue
+		}
+		var first *ast.CallExpr
+		var name string
+		read := false
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ExprStmt:
+				// A mutating call on v whose result is discarded only
+				// feeds v, even if v is among its arguments.
+				call, ok := n.X.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, fn, ok := intMethod(pass, call)
+				if !ok || readOnly(fn) {
+					return true
+				}
+				if recv, ok := ast.Unparen(sel.X).(*ast.Ident); ok && pass.TypesInfo.Uses[recv] == obj {
+					if first == nil {
+						first, name = call, fn.Name()
+					}
+					return false
+				}
+			case *ast.Ident:
+				if pass.TypesInfo.Uses[n] == obj {
+					read = true
+				}
+			}
+			return !read
+		})
+		if first != nil && !read {
+			pass.Reportf(first.Pos(), "%s is a copy of %s; (*uint256.Int).%s modifies the copy, which is never read", id.Name, types.ExprString(star), name)
+		}
+	}
+}
+
+// intMethod returns the selector and the method if call invokes a method
+// of uint256.Int.
+func intMethod(pass *analysis.Pass, call *ast.CallExpr) (*ast.SelectorExpr, *types.Func, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	selection := pass.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil, nil, false
+	}
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if !isInt(recv) {
+		return nil, nil, false
+	}
+	return sel, selection.Obj().(*types.Func), true
+}
+
+// isTemporary reports whether expr yields an Int that nothing else refers
+// to: new(Int), &Int{...}, NewInt(...), x.Clone(), or a method chain rooted
+// at one of those.
+func isTemporary(pass *analysis.Pass, expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		_, ok := e.X.(*ast.CompositeLit)
+		return ok
+	case *ast.CallExpr:
+		if id, ok := ast.Unparen(e.Fun).(*ast.Ident); ok {
+			if b, ok := pass.TypesInfo.Uses[id].(*types.Builtin); ok {
+				return b.Name() == "new"
+			}
+		}
+		if fn, ok := typeutil.Callee(pass.TypesInfo, e).(*types.Func); ok && fn.Pkg() != nil && fn.Pkg().Path() == pkgPath {
+			if fn.Name() == "NewInt" || fn.Name() == "Clone" {
+				return true
+			}
+		}
+		if sel, _, ok := intMethod(pass, e); ok {
+			return isTemporary(pass, sel.X)
+		}
+	}
+	return false
+}
+
+// isIntPtr reports whether t is *uint256.Int.
+func isIntPtr(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	return ok && isInt(ptr.Elem())
+}
+
+// isInt reports whether t is the named type uint256.Int.
+func isInt(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == "Int" && obj.Pkg() != nil && obj.Pkg().Path() == pkgPath
+}
@@ -0,0 +1,16 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256check_test
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256/analysis/uint256check"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), uint256check.Analyzer, "a")
+}
@@ -0,0 +1,75 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "errors"
+
+var (
+	ErrAvroNegative   = errors.New("avro decimal: negative value")
+	ErrAvroFractional = errors.New("avro decimal: value is not an integer")
+	ErrAvroRange      = errors.New("avro decimal: value > 256 bits")
+)
+
+// maxPow10 is the largest n such that 10**n fits in 256 bits.
+const maxPow10 = 77
+
+// MarshalAvroDecimal encodes z as the bytes of an Avro decimal logical
+// type with the given scale: the big-endian two's complement encoding of
+// the unscaled value z * 10**scale, in the minimal number of bytes.
+// It returns ErrAvroRange if the unscaled value does not fit in 256 bits.
+func (z *Int) MarshalAvroDecimal(scale uint) ([]byte, error) {
+	unscaled := *z
+	if !z.IsZero() && scale > 0 {
+		if scale > maxPow10 {
+			return nil, ErrAvroRange
+		}
+		var p Int
+		p.Exp(&Int{10}, &Int{uint64(scale)})
+		if _, overflow := unscaled.MulOverflow(&unscaled, &p); overflow {
+			return nil, ErrAvroRange
+		}
+	}
+	// One extra bit for the (positive) sign.
+	n := unscaled.BitLen()/8 + 1
+	b := unscaled.Bytes32()
+	if n > 32 {
+		return append([]byte{0}, b[:]...), nil
+	}
+	out := make([]byte, n)
+	copy(out, b[32-n:])
+	return out, nil
+}
+
+// UnmarshalAvroDecimal sets z to the value of an Avro decimal with the
+// given scale, encoded as big-endian two's complement bytes.
+// It returns ErrAvroNegative for negative values, ErrAvroFractional if the
+// decimal is not a whole number, and ErrAvroRange if it exceeds 256 bits.
+func (z *Int) UnmarshalAvroDecimal(b []byte, scale uint) error {
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		return ErrAvroNegative
+	}
+	for len(b) > 32 {
+		if b[0] != 0 {
+			return ErrAvroRange
+		}
+		b = b[1:]
+	}
+	var unscaled Int
+	unscaled.SetBytes(b)
+	if scale == 0 || unscaled.IsZero() {
+		z.Set(&unscaled)
+		return nil
+	}
+	if scale > maxPow10 {
+		return ErrAvroFractional
+	}
+	var p, rem Int
+	p.Exp(&Int{10}, &Int{uint64(scale)})
+	if !rem.Mod(&unscaled, &p).IsZero() {
+		return ErrAvroFractional
+	}
+	z.Div(&unscaled, &p)
+	return nil
+}
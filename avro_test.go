@@ -0,0 +1,83 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+// avroRef returns the minimal big-endian two's complement encoding of the
+// non-negative b, as produced by Java's BigInteger.toByteArray.
+func avroRef(b *big.Int) []byte {
+	out := b.Bytes()
+	if len(out) == 0 || out[0]&0x80 != 0 {
+		out = append([]byte{0}, out...)
+	}
+	return out
+}
+
+func TestAvroDecimal(t *testing.T) {
+	for _, scale := range []uint{0, 1, 2, 18, 50} {
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+		for i := 0; i < 200; i++ {
+			b, f, err := randNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			unscaled := new(big.Int).Mul(b, pow)
+			enc, err := f.MarshalAvroDecimal(scale)
+			if unscaled.BitLen() > 256 {
+				if err != ErrAvroRange {
+					t.Fatalf("expected ErrAvroRange for %x scale %d, got %v", b, scale, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := avroRef(unscaled); !bytes.Equal(enc, want) {
+				t.Fatalf("%x scale %d: got %x, want %x", b, scale, enc, want)
+			}
+			var back Int
+			if err := back.UnmarshalAvroDecimal(enc, scale); err != nil || !back.Eq(f) {
+				t.Fatalf("%x scale %d: round trip got %x, %v", b, scale, &back, err)
+			}
+		}
+	}
+	max := new(Int).SetAllOne()
+	enc, err := max.MarshalAvroDecimal(0)
+	if err != nil || len(enc) != 33 || enc[0] != 0 {
+		t.Errorf("max value: got %x, %v", enc, err)
+	}
+	if enc, _ := new(Int).MarshalAvroDecimal(100); !bytes.Equal(enc, []byte{0}) {
+		t.Errorf("zero: got %x", enc)
+	}
+}
+
+func TestAvroDecimalErrors(t *testing.T) {
+	var z Int
+	if err := z.UnmarshalAvroDecimal([]byte{0xff}, 0); err != ErrAvroNegative {
+		t.Errorf("got %v, want ErrAvroNegative", err)
+	}
+	// 1.5 with scale 1.
+	if err := z.UnmarshalAvroDecimal([]byte{15}, 1); err != ErrAvroFractional {
+		t.Errorf("got %v, want ErrAvroFractional", err)
+	}
+	if err := z.UnmarshalAvroDecimal([]byte{15}, 100); err != ErrAvroFractional {
+		t.Errorf("got %v, want ErrAvroFractional", err)
+	}
+	if err := z.UnmarshalAvroDecimal(append([]byte{0, 1}, make([]byte, 32)...), 0); err != ErrAvroRange {
+		t.Errorf("got %v, want ErrAvroRange", err)
+	}
+	// Redundant sign-extension bytes are accepted.
+	if err := z.UnmarshalAvroDecimal([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 200}, 1); err != nil || !z.Eq(NewInt(20)) {
+		t.Errorf("got %d, %v", &z, err)
+	}
+	if _, err := NewInt(1).MarshalAvroDecimal(78); err != ErrAvroRange {
+		t.Errorf("got %v, want ErrAvroRange", err)
+	}
+}
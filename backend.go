@@ -0,0 +1,159 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// BatchBackend performs modular arithmetic on batches of Ints. The
+// package-level MulModBatch, ExpModBatch and NTT functions dispatch to the
+// backend installed with SetBatchBackend, so that applications can plug in
+// GPU or FPGA accelerators without changing the calling code.
+//
+// Implementations must produce the same results as GoBackend, including
+// the handling of m == 0, and must be safe for concurrent use.
+type BatchBackend interface {
+	// MulModBatch sets z[i] to x[i]*y[i] mod m for every i.
+	MulModBatch(z, x, y []Int, m *Int)
+	// ExpModBatch sets z[i] to base[i]**exp[i] mod m for every i.
+	ExpModBatch(z, base, exp []Int, m *Int)
+	// NTT replaces a by its number-theoretic transform modulo m:
+	// a'[k] = sum a[j]*omega**(j*k), where omega is a primitive len(a)-th
+	// root of unity modulo the prime m, and len(a) is a power of two.
+	NTT(a []Int, omega, m *Int)
+}
+
+// GoBackend is the pure Go BatchBackend used by default. Accelerated
+// backends may embed it to fall back for operations they do not support.
+type GoBackend struct{}
+
+type backendHolder struct{ b BatchBackend }
+
+var batchBackend atomic.Value
+
+func init() {
+	batchBackend.Store(backendHolder{GoBackend{}})
+}
+
+// SetBatchBackend installs b as the backend for the batch functions. A nil
+// b restores GoBackend.
+func SetBatchBackend(b BatchBackend) {
+	if b == nil {
+		b = GoBackend{}
+	}
+	batchBackend.Store(backendHolder{b})
+}
+
+// CurrentBatchBackend returns the installed batch backend.
+func CurrentBatchBackend() BatchBackend {
+	return batchBackend.Load().(backendHolder).b
+}
+
+func checkBatchLen(n int, xs ...[]Int) {
+	for _, x := range xs {
+		if len(x) != n {
+			panic("uint256: batch length mismatch")
+		}
+	}
+}
+
+// MulModBatch sets z[i] to x[i]*y[i] mod m for every i, using the
+// installed batch backend. All slices must have the same length.
+// If m == 0, z is set to zeros (OBS: differs from the big.Int)
+func MulModBatch(z, x, y []Int, m *Int) {
+	if instrumented {
+		defer region("MulModBatch")()
+	}
+	checkBatchLen(len(z), x, y)
+	CurrentBatchBackend().MulModBatch(z, x, y, m)
+}
+
+// ExpModBatch sets z[i] to base[i]**exp[i] mod m for every i, using the
+// installed batch backend. All slices must have the same length.
+// If m == 0, z is set to zeros (OBS: differs from the big.Int)
+func ExpModBatch(z, base, exp []Int, m *Int) {
+	if instrumented {
+		defer region("ExpModBatch")()
+	}
+	checkBatchLen(len(z), base, exp)
+	CurrentBatchBackend().ExpModBatch(z, base, exp, m)
+}
+
+// NTT replaces a by its number-theoretic transform modulo the prime m,
+// a'[k] = sum a[j]*omega**(j*k), using the installed batch backend. omega
+// must be a primitive len(a)-th root of unity modulo m, and len(a) a power
+// of two. The inverse transform is NTT with omega**-1, followed by
+// multiplying each element by len(a)**-1.
+// If m == 0, a is set to zeros (OBS: differs from the big.Int)
+func NTT(a []Int, omega, m *Int) {
+	if instrumented {
+		defer region("NTT")()
+	}
+	if len(a)&(len(a)-1) != 0 {
+		panic("uint256: NTT length is not a power of two")
+	}
+	CurrentBatchBackend().NTT(a, omega, m)
+}
+
+// MulModBatch implements BatchBackend.
+func (GoBackend) MulModBatch(z, x, y []Int, m *Int) {
+	for i := range z {
+		z[i].MulMod(&x[i], &y[i], m)
+	}
+}
+
+// ExpModBatch implements BatchBackend.
+func (GoBackend) ExpModBatch(z, base, exp []Int, m *Int) {
+	for i := range z {
+		z[i].ExpMod(&base[i], &exp[i], m)
+	}
+}
+
+// NTT implements BatchBackend, with an iterative radix-2 Cooley-Tukey
+// transform.
+func (GoBackend) NTT(a []Int, omega, m *Int) {
+	if m.IsZero() {
+		for i := range a {
+			a[i].Clear()
+		}
+		return
+	}
+	n := len(a)
+	if n <= 1 {
+		if n == 1 {
+			a[0].Mod(&a[0], m)
+		}
+		return
+	}
+	logN := uint(bits.TrailingZeros(uint(n)))
+	for i := range a {
+		a[i].Mod(&a[i], m)
+		if j := int(bits.Reverse(uint(i)) >> (bits.UintSize - logN)); i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	// At each stage, twiddles[k] = w**k for the primitive (2*half)-th root
+	// of unity w = omega**(n/(2*half)).
+	twiddles := make([]Int, n/2)
+	var w Int
+	for half := 1; half < n; half *= 2 {
+		w.ExpMod(omega, new(Int).SetUint64(uint64(n/(2*half))), m)
+		twiddles[0].Mod(&Int{1}, m)
+		for k := 1; k < half; k++ {
+			twiddles[k].MulMod(&twiddles[k-1], &w, m)
+		}
+		for start := 0; start < n; start += 2 * half {
+			for k := 0; k < half; k++ {
+				u := a[start+k]
+				var v Int
+				v.MulMod(&a[start+k+half], &twiddles[k], m)
+				a[start+k].AddMod(&u, &v, m)
+				a[start+k+half].subModReduced(&u, &v, m)
+			}
+		}
+	}
+}
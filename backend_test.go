@@ -0,0 +1,113 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBatchOps(t *testing.T) {
+	const n = 20
+	bm, m, _ := randNums()
+	for m.IsZero() {
+		bm, m, _ = randNums()
+	}
+	x, y, z := make([]Int, n), make([]Int, n), make([]Int, n)
+	bx, by := make([]*big.Int, n), make([]*big.Int, n)
+	for i := range x {
+		var f *Int
+		bx[i], f, _ = randNums()
+		x[i] = *f
+		by[i], f, _ = randNums()
+		y[i] = *f
+	}
+	MulModBatch(z, x, y, m)
+	for i := range z {
+		requireEq(t, new(big.Int).Mod(new(big.Int).Mul(bx[i], by[i]), bm), &z[i], "MulModBatch")
+	}
+	ExpModBatch(z, x, y, m)
+	for i := range z {
+		requireEq(t, new(big.Int).Exp(bx[i], by[i], bm), &z[i], "ExpModBatch")
+	}
+	MulModBatch(z, x, y, new(Int))
+	for i := range z {
+		if !z[i].IsZero() {
+			t.Fatalf("expected zeros for zero modulus")
+		}
+	}
+}
+
+func TestNTT(t *testing.T) {
+	// 998244353 = 119 * 2**23 + 1, with primitive root 3.
+	p := NewInt(998244353)
+	// The BLS12-381 scalar field, with 2**32-th root of unity 7**((r-1)/2**32).
+	r, _ := FromHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	for _, tc := range []struct{ m, g *Int }{{p, NewInt(3)}, {r, NewInt(7)}} {
+		for logN := uint(0); logN <= 6; logN++ {
+			size := 1 << logN
+			// omega = g**((m-1)/size)
+			var e, omega Int
+			e.SubUint64(tc.m, 1).Rsh(&e, logN)
+			omega.ExpMod(tc.g, &e, tc.m)
+
+			a := make([]Int, size)
+			for i := range a {
+				_, f, _ := randNums()
+				a[i] = *f
+			}
+			orig := append([]Int{}, a...)
+			NTT(a, &omega, tc.m)
+			var wk Int
+			wk.SetOne()
+			for k := range a {
+				want := new(Int).EvalPolyMod(orig, &wk, tc.m)
+				if !a[k].Eq(want) {
+					t.Fatalf("m=%v n=%d: NTT[%d] = %v, want %v", tc.m, size, k, &a[k], want)
+				}
+				wk.MulMod(&wk, &omega, tc.m)
+			}
+			// Inverse transform.
+			var omegaInv, nInv Int
+			omegaInv.InvModPrime(&omega, tc.m)
+			nInv.InvModPrime(NewInt(uint64(size)), tc.m)
+			NTT(a, &omegaInv, tc.m)
+			for i := range a {
+				a[i].MulMod(&a[i], &nInv, tc.m)
+				if want := new(Int).Mod(&orig[i], tc.m); !a[i].Eq(want) {
+					t.Fatalf("m=%v n=%d: inverse NTT[%d] = %v, want %v", tc.m, size, i, &a[i], want)
+				}
+			}
+		}
+	}
+}
+
+type countingBackend struct {
+	GoBackend
+	calls int
+}
+
+func (b *countingBackend) MulModBatch(z, x, y []Int, m *Int) {
+	b.calls++
+	b.GoBackend.MulModBatch(z, x, y, m)
+}
+
+func TestSetBatchBackend(t *testing.T) {
+	b := new(countingBackend)
+	SetBatchBackend(b)
+	defer SetBatchBackend(nil)
+	if CurrentBatchBackend() != b {
+		t.Fatalf("backend not installed")
+	}
+	z := make([]Int, 1)
+	MulModBatch(z, []Int{{3}}, []Int{{5}}, NewInt(7))
+	if b.calls != 1 || !z[0].Eq(NewInt(1)) {
+		t.Errorf("got calls=%d z=%v, want 1, 1", b.calls, &z[0])
+	}
+	SetBatchBackend(nil)
+	if _, ok := CurrentBatchBackend().(GoBackend); !ok {
+		t.Errorf("nil did not restore GoBackend")
+	}
+}
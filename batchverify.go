@@ -0,0 +1,81 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+)
+
+// BatchVerifier checks many modular equalities a_i ≡ b_i (mod m) at once,
+// by testing the random linear combination
+//
+//	sum r_i*a_i ≡ sum r_i*b_i (mod m)
+//
+// with 128-bit weights r_i read from a caller-supplied source of randomness.
+// If any equality fails, Verify reports false except with probability at
+// most 2**-128 for a prime m (in general, 1/q for the smallest prime factor
+// q of m, if q < 2**128).
+//
+// The weighted sums are accumulated in 512 bits without reduction, so that
+// each pair costs two multiplications and only Verify performs a modular
+// reduction. At most 2**128 pairs may be added.
+type BatchVerifier struct {
+	m        Int
+	r        io.Reader
+	lhs, rhs [8]uint64
+	n        int
+}
+
+// NewBatchVerifier returns a verifier for equalities modulo m, drawing the
+// weights from r (typically crypto/rand.Reader).
+func NewBatchVerifier(m *Int, r io.Reader) *BatchVerifier {
+	return &BatchVerifier{m: *m, r: r}
+}
+
+// addWide adds x to the 512-bit accumulator acc.
+func addWide(acc *[8]uint64, x *[8]uint64) {
+	var carry uint64
+	for i := range acc {
+		acc[i], carry = bits.Add64(acc[i], x[i], carry)
+	}
+}
+
+// Add adds the equality a ≡ b (mod m) to the batch. It returns an error
+// only if reading the weight fails.
+func (v *BatchVerifier) Add(a, b *Int) error {
+	var buf [16]byte
+	if _, err := io.ReadFull(v.r, buf[:]); err != nil {
+		return err
+	}
+	w := Int{binary.LittleEndian.Uint64(buf[:8]), binary.LittleEndian.Uint64(buf[8:])}
+	pa, pb := umul(a, &w), umul(b, &w)
+	addWide(&v.lhs, &pa)
+	addWide(&v.rhs, &pb)
+	v.n++
+	return nil
+}
+
+// Len returns the number of equalities added since the last Reset.
+func (v *BatchVerifier) Len() int {
+	return v.n
+}
+
+// Verify reports whether all equalities added so far hold, with the error
+// probability given above. An empty batch verifies. If m == 0, the
+// equalities are checked over the integers.
+func (v *BatchVerifier) Verify() bool {
+	if v.m.IsZero() {
+		return v.lhs == v.rhs
+	}
+	l, r := reduce512(&v.lhs, &v.m), reduce512(&v.rhs, &v.m)
+	return l.Eq(&r)
+}
+
+// Reset removes all equalities from the batch.
+func (v *BatchVerifier) Reset() {
+	v.lhs, v.rhs, v.n = [8]uint64{}, [8]uint64{}, 0
+}
@@ -0,0 +1,77 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	p := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	for _, m := range []*Int{p, NewInt(1 << 61), new(Int)} {
+		v := NewBatchVerifier(m, rand.Reader)
+		if !v.Verify() {
+			t.Errorf("m=%v: empty batch does not verify", m)
+		}
+		var xs, ys []Int
+		for i := 0; i < 100; i++ {
+			_, x, err := randNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var y Int
+			y.Mod(x, m)
+			if m.IsZero() {
+				y = *x
+			}
+			xs, ys = append(xs, *x), append(ys, y)
+			if err := v.Add(x, &y); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if v.Len() != 100 || !v.Verify() {
+			t.Errorf("m=%v: valid batch of %d does not verify", m, v.Len())
+		}
+		// Break a single equality.
+		for bad := range xs {
+			v.Reset()
+			for i := range xs {
+				y := ys[i]
+				if i == bad {
+					y.AddUint64(&y, 1)
+				}
+				if err := v.Add(&xs[i], &y); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if v.Verify() {
+				t.Fatalf("m=%v: batch with bad pair %d verifies", m, bad)
+			}
+		}
+	}
+	v := NewBatchVerifier(p, bytes.NewReader(make([]byte, 20)))
+	if err := v.Add(NewInt(1), NewInt(1)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := v.Add(NewInt(1), NewInt(1)); err == nil {
+		t.Errorf("expected error from exhausted reader")
+	}
+}
+
+func BenchmarkBatchVerifier(b *testing.B) {
+	m, _ := FromHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	v := NewBatchVerifier(m, rand.Reader)
+	for i := 0; i < b.N; i++ {
+		if err := v.Add(x, x); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if !v.Verify() {
+		b.Fatal("batch does not verify")
+	}
+}
@@ -669,6 +669,55 @@ func BenchmarkMulMod(b *testing.B) {
 	b.Run("mod256/big", func(b *testing.B) { benchmarkMulModBig(b, &big256Samples, &big256SamplesLt) })
 }
 
+func BenchmarkSqrMod(b *testing.B) {
+	benchmarkSqrModUint256 := func(b *testing.B, samples, modSamples *[numSamples]Int) {
+		var sink Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				sink.SqrMod(&samples[i], &modSamples[i])
+			}
+		}
+	}
+	benchmarkMulModUint256 := func(b *testing.B, samples, modSamples *[numSamples]Int) {
+		var sink Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				sink.MulMod(&samples[i], &samples[i], &modSamples[i])
+			}
+		}
+	}
+
+	b.Run("mod256/uint256", func(b *testing.B) { benchmarkSqrModUint256(b, &int256Samples, &int256SamplesLt) })
+	b.Run("mod256/MulMod", func(b *testing.B) { benchmarkMulModUint256(b, &int256Samples, &int256SamplesLt) })
+	b.Run("mod128/uint256", func(b *testing.B) { benchmarkSqrModUint256(b, &int256Samples, &int128Samples) })
+	b.Run("mod128/MulMod", func(b *testing.B) { benchmarkMulModUint256(b, &int256Samples, &int128Samples) })
+}
+
+func BenchmarkMulDivOverflow(b *testing.B) {
+	benchmarkMulDivUint256 := func(b *testing.B, samples, divSamples *[numSamples]Int) {
+		var sink Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				sink.MulDivOverflow(&samples[i], &samples[i], &divSamples[i])
+			}
+		}
+	}
+	benchmarkMulDivBig := func(b *testing.B, samples, divSamples *[numSamples]big.Int) {
+		var sink big.Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				sink.Mul(&samples[i], &samples[i])
+				sink.Quo(&sink, &divSamples[i])
+			}
+		}
+	}
+
+	b.Run("div256/uint256", func(b *testing.B) { benchmarkMulDivUint256(b, &int256Samples, &int256SamplesLt) })
+	b.Run("div256/big", func(b *testing.B) { benchmarkMulDivBig(b, &big256Samples, &big256SamplesLt) })
+	b.Run("div128/uint256", func(b *testing.B) { benchmarkMulDivUint256(b, &int256Samples, &int128Samples) })
+	b.Run("div128/big", func(b *testing.B) { benchmarkMulDivBig(b, &big256Samples, &big128Samples) })
+}
+
 func benchmark_SdivLarge_Big(bench *testing.B) {
 	a := new(big.Int).SetBytes(hex2Bytes("800fffffffffffffffffffffffffd1e870eec79504c60144cc7f5fc2bad1e611"))
 	b := new(big.Int).SetBytes(hex2Bytes("ff3f9014f20db29ae04af2c2d265de17"))
@@ -0,0 +1,151 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "math/bits"
+
+// HammingDistance returns the number of bit positions in which x and y
+// differ, i.e. the population count of x ^ y.
+func HammingDistance(x, y *Int) int {
+	return bits.OnesCount64(x[0]^y[0]) + bits.OnesCount64(x[1]^y[1]) +
+		bits.OnesCount64(x[2]^y[2]) + bits.OnesCount64(x[3]^y[3])
+}
+
+// IsolateLowestSet sets z = x & -x, keeping only the lowest set bit of x,
+// and returns z. If x == 0, z is set to 0.
+func (z *Int) IsolateLowestSet(x *Int) *Int {
+	var neg Int
+	neg.Neg(x)
+	return z.And(x, &neg)
+}
+
+// ClearLowestSet sets z = x & (x-1), clearing the lowest set bit of x,
+// and returns z. If x == 0, z is set to 0.
+func (z *Int) ClearLowestSet(x *Int) *Int {
+	var xm1 Int
+	xm1.SubUint64(x, 1)
+	return z.And(x, &xm1)
+}
+
+// Bit returns the value of bit n of z, 0 or 1, where bit 0 is the least
+// significant. Bits at n > 255 are 0.
+func (z *Int) Bit(n uint) uint {
+	if n > 255 {
+		return 0
+	}
+	return uint(z[n/64]>>(n%64)) & 1
+}
+
+// SetBit sets z to x, with bit n set to b, and returns z. Any nonzero b sets
+// the bit (OBS: differs from the big.Int, which panics unless b is 0 or 1).
+// Bits at n > 255 do not exist, so there z is set to x.
+func (z *Int) SetBit(x *Int, n uint, b uint) *Int {
+	if b == 0 {
+		return z.ClearBit(x, n)
+	}
+	z.Set(x)
+	if n < 256 {
+		z[n/64] |= 1 << (n % 64)
+	}
+	return z
+}
+
+// ClearBit sets z to x, with bit n cleared, and returns z.
+func (z *Int) ClearBit(x *Int, n uint) *Int {
+	z.Set(x)
+	if n < 256 {
+		z[n/64] &^= 1 << (n % 64)
+	}
+	return z
+}
+
+// ToggleBit sets z to x, with bit n flipped, and returns z.
+func (z *Int) ToggleBit(x *Int, n uint) *Int {
+	z.Set(x)
+	if n < 256 {
+		z[n/64] ^= 1 << (n % 64)
+	}
+	return z
+}
+
+// ReverseBits sets z to x with the order of its 256 bits reversed, and
+// returns z.
+func (z *Int) ReverseBits(x *Int) *Int {
+	z[0], z[1], z[2], z[3] = bits.Reverse64(x[3]), bits.Reverse64(x[2]),
+		bits.Reverse64(x[1]), bits.Reverse64(x[0])
+	return z
+}
+
+// ReverseBytes sets z to x with the order of its 32 bytes reversed, and
+// returns z. It converts between the big- and little-endian readings of the
+// same 32 bytes.
+func (z *Int) ReverseBytes(x *Int) *Int {
+	z[0], z[1], z[2], z[3] = bits.ReverseBytes64(x[3]), bits.ReverseBytes64(x[2]),
+		bits.ReverseBytes64(x[1]), bits.ReverseBytes64(x[0])
+	return z
+}
+
+// RotL sets z to x rotated left by k bits, modulo 256, and returns z.
+func (z *Int) RotL(x *Int, k uint) *Int {
+	var (
+		t = *x
+		w = k / 64 % 4
+		s = k % 64
+	)
+	// A shift by 64 yields 0, so s == 0 needs no special case.
+	for i := uint(0); i < 4; i++ {
+		z[i] = t[(i-w)%4]<<s | t[(i-w-1)%4]>>(64-s)
+	}
+	return z
+}
+
+// RotR sets z to x rotated right by k bits, modulo 256, and returns z.
+func (z *Int) RotR(x *Int, k uint) *Int {
+	return z.RotL(x, 256-k%256)
+}
+
+// LeadingZeros returns the number of leading zero bits in z; the result is
+// 256 for z == 0.
+func (z *Int) LeadingZeros() int {
+	return 256 - z.BitLen()
+}
+
+// TrailingZeros returns the number of trailing zero bits in z; the result is
+// 256 for z == 0.
+func (z *Int) TrailingZeros() int {
+	for i, w := range z {
+		if w != 0 {
+			return i*64 + bits.TrailingZeros64(w)
+		}
+	}
+	return 256
+}
+
+// OnesCount returns the number of one bits ("population count") in z.
+func (z *Int) OnesCount() int {
+	return bits.OnesCount64(z[0]) + bits.OnesCount64(z[1]) +
+		bits.OnesCount64(z[2]) + bits.OnesCount64(z[3])
+}
+
+// Parity returns 1 if the number of set bits in z is odd, and 0 otherwise.
+func (z *Int) Parity() uint {
+	return uint(bits.OnesCount64(z[0]^z[1]^z[2]^z[3]) & 1)
+}
+
+// NextPowerOfTwo sets z to the smallest power of two that is >= x, and
+// returns z. NextPowerOfTwo(0) is 1. If x > 2**255 the result does not fit,
+// and z is set to 0 (2**256 mod 2**256).
+func (z *Int) NextPowerOfTwo(x *Int) *Int {
+	if x.IsZero() {
+		return z.SetOne()
+	}
+	var xm1 Int
+	xm1.SubUint64(x, 1)
+	n := uint(xm1.BitLen())
+	if n == 256 {
+		return z.Clear()
+	}
+	return z.Lsh(z.SetOne(), n)
+}
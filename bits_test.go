@@ -0,0 +1,162 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// bigOnesCount returns the number of set bits in the non-negative b.
+func bigOnesCount(b *big.Int) int {
+	n := 0
+	for i := 0; i < b.BitLen(); i++ {
+		n += int(b.Bit(i))
+	}
+	return n
+}
+
+func TestHammingDistance(t *testing.T) {
+	for i := 0; i < len(binTestCases); i++ {
+		b1, _ := new(big.Int).SetString(binTestCases[i][0], 0)
+		b2, _ := new(big.Int).SetString(binTestCases[i][1], 0)
+		f1, _ := FromBig(b1)
+		f2, _ := FromBig(b2)
+		want := bigOnesCount(new(big.Int).Xor(b1, b2))
+		if got := HammingDistance(f1, f2); got != want {
+			t.Errorf("args: %s, %s: got %d, want %d", binTestCases[i][0], binTestCases[i][1], got, want)
+		}
+	}
+	if got := HammingDistance(new(Int), new(Int).SetAllOne()); got != 256 {
+		t.Errorf("got %d, want 256", got)
+	}
+}
+
+func TestBitTricks(t *testing.T) {
+	for i := 0; i < len(unTestCases); i++ {
+		b, _ := new(big.Int).SetString(unTestCases[i], 0)
+		f, _ := FromBig(b)
+
+		// x & -x
+		want := new(big.Int).And(b, new(big.Int).Sub(bigtt256, b))
+		requireEq(t, want, new(Int).IsolateLowestSet(f), "IsolateLowestSet")
+		// x & (x-1)
+		want = new(big.Int).Sub(b, big.NewInt(1))
+		want.And(want.Mod(want, bigtt256), b)
+		requireEq(t, want, new(Int).ClearLowestSet(f), "ClearLowestSet")
+
+		if got, want := f.Parity(), uint(bigOnesCount(b)%2); got != want {
+			t.Errorf("Parity(%x): got %d, want %d", b, got, want)
+		}
+
+		want = big.NewInt(1)
+		for want.Cmp(b) < 0 {
+			want.Lsh(want, 1)
+		}
+		requireEq(t, want.Mod(want, bigtt256), new(Int).NextPowerOfTwo(f), "NextPowerOfTwo")
+
+		// Aliasing.
+		g := f.Clone()
+		if !g.IsolateLowestSet(g).Eq(new(Int).IsolateLowestSet(f)) {
+			t.Error("IsolateLowestSet aliasing")
+		}
+		g = f.Clone()
+		if !g.ClearLowestSet(g).Eq(new(Int).ClearLowestSet(f)) {
+			t.Error("ClearLowestSet aliasing")
+		}
+		g = f.Clone()
+		if !g.NextPowerOfTwo(g).Eq(new(Int).NextPowerOfTwo(f)) {
+			t.Error("NextPowerOfTwo aliasing")
+		}
+	}
+	for _, x := range []uint64{2, 3, 4, 5, 1 << 63, 1<<63 + 1} {
+		want := new(big.Int).Lsh(big.NewInt(1), uint(new(big.Int).SetUint64(x-1).BitLen()))
+		requireEq(t, want, new(Int).NextPowerOfTwo(NewInt(x)), "NextPowerOfTwo")
+	}
+}
+
+func TestBitCounts(t *testing.T) {
+	for i := 0; i < len(unTestCases); i++ {
+		b, _ := new(big.Int).SetString(unTestCases[i], 0)
+		f, _ := FromBig(b)
+		if got, want := f.LeadingZeros(), 256-b.BitLen(); got != want {
+			t.Errorf("LeadingZeros(%s): got %d, want %d", unTestCases[i], got, want)
+		}
+		want := 256
+		if b.Sign() != 0 {
+			want = int(b.TrailingZeroBits())
+		}
+		if got := f.TrailingZeros(); got != want {
+			t.Errorf("TrailingZeros(%s): got %d, want %d", unTestCases[i], got, want)
+		}
+		if got, want := f.OnesCount(), bigOnesCount(b); got != want {
+			t.Errorf("OnesCount(%s): got %d, want %d", unTestCases[i], got, want)
+		}
+	}
+	for i := uint(0); i < 256; i++ {
+		f := new(Int).Lsh(NewInt(1), i)
+		if f.LeadingZeros() != int(255-i) || f.TrailingZeros() != int(i) || f.OnesCount() != 1 {
+			t.Errorf("2**%d: got %d leading, %d trailing, %d ones", i, f.LeadingZeros(), f.TrailingZeros(), f.OnesCount())
+		}
+	}
+}
+
+func TestBitAccess(t *testing.T) {
+	for i := 0; i < len(unTestCases); i++ {
+		b, _ := new(big.Int).SetString(unTestCases[i], 0)
+		f, _ := FromBig(b)
+		for _, n := range []uint{0, 1, 63, 64, 65, 127, 128, 191, 192, 254, 255, 256, 1000} {
+			if got, want := f.Bit(n), b.Bit(int(n)); got != want {
+				t.Errorf("Bit(%s, %d): got %d, want %d", unTestCases[i], n, got, want)
+			}
+			want := new(big.Int).SetBit(b, int(n), 1)
+			requireEq(t, want.Mod(want, bigtt256), new(Int).SetBit(f, n, 1), "SetBit 1")
+			requireEq(t, new(big.Int).SetBit(b, int(n), 0), new(Int).SetBit(f, n, 0), "SetBit 0")
+			requireEq(t, new(big.Int).SetBit(b, int(n), 0), new(Int).ClearBit(f, n), "ClearBit")
+			want.SetBit(b, int(n), b.Bit(int(n))^1)
+			requireEq(t, want.Mod(want, bigtt256), new(Int).ToggleBit(f, n), "ToggleBit")
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for i := 0; i < len(unTestCases); i++ {
+		b, _ := new(big.Int).SetString(unTestCases[i], 0)
+		f, _ := FromBig(b)
+		want := new(big.Int)
+		for n := 0; n < 256; n++ {
+			want.SetBit(want, 255-n, b.Bit(n))
+		}
+		requireEq(t, want, new(Int).ReverseBits(f), "ReverseBits")
+
+		be := f.Bytes32()
+		for l, r := 0, len(be)-1; l < r; l, r = l+1, r-1 {
+			be[l], be[r] = be[r], be[l]
+		}
+		requireEq(t, new(big.Int).SetBytes(be[:]), new(Int).ReverseBytes(f), "ReverseBytes")
+		if got := new(Int).ReverseBits(new(Int).ReverseBits(f)); !got.Eq(f) {
+			t.Errorf("ReverseBits twice: got %x, want %x", got, f)
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for i := 0; i < len(unTestCases); i++ {
+		b, _ := new(big.Int).SetString(unTestCases[i], 0)
+		f, _ := FromBig(b)
+		for _, k := range []uint{0, 1, 7, 63, 64, 65, 100, 128, 191, 192, 255, 256, 257, 1000} {
+			r := k % 256
+			// (b << r | b >> (256-r)) mod 2**256
+			want := new(big.Int).Lsh(b, r)
+			want.Or(want, new(big.Int).Rsh(b, 256-r))
+			want.Mod(want, bigtt256)
+			requireEq(t, want, new(Int).RotL(f, k), fmt.Sprintf("RotL(%s, %d)", unTestCases[i], k))
+			if got := new(Int).RotR(new(Int).RotL(f, k), k); !got.Eq(f) {
+				t.Errorf("RotR(RotL(%s, %d)): got %x", unTestCases[i], k, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// Chain evaluates a sequence of checked arithmetic operations, as in
+//
+//	v, err := NewChain(x).Mul(y).AddMod(z, m).Value()
+//
+// The first operation to fail records its error (ErrOverflow, ErrUnderflow
+// or ErrDivByZero) and turns all following operations into no-ops, so the
+// error only needs to be checked once at the end.
+type Chain struct {
+	v   Int
+	err error
+}
+
+// NewChain returns a chain whose current value is a copy of x.
+func NewChain(x *Int) *Chain {
+	return &Chain{v: *x}
+}
+
+// Value returns a copy of the current value and the first error
+// encountered, if any. If the error is non-nil, the value is the one
+// before the failing operation.
+func (c *Chain) Value() (*Int, error) {
+	return c.v.Clone(), c.err
+}
+
+// Err returns the first error encountered, if any.
+func (c *Chain) Err() error {
+	return c.err
+}
+
+// Add sets the value to value+y, failing with ErrOverflow.
+func (c *Chain) Add(y *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedAdd(&c.v, y)
+	}
+	return c
+}
+
+// Sub sets the value to value-y, failing with ErrUnderflow.
+func (c *Chain) Sub(y *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedSub(&c.v, y)
+	}
+	return c
+}
+
+// Mul sets the value to value*y, failing with ErrOverflow.
+func (c *Chain) Mul(y *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedMul(&c.v, y)
+	}
+	return c
+}
+
+// Div sets the value to value/y, failing with ErrDivByZero.
+func (c *Chain) Div(y *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedDiv(&c.v, y)
+	}
+	return c
+}
+
+// Mod sets the value to value%y, failing with ErrDivByZero.
+func (c *Chain) Mod(y *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedMod(&c.v, y)
+	}
+	return c
+}
+
+// AddMod sets the value to (value+y) mod m, failing with ErrDivByZero.
+func (c *Chain) AddMod(y, m *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedAddMod(&c.v, y, m)
+	}
+	return c
+}
+
+// MulMod sets the value to (value*y) mod m, failing with ErrDivByZero.
+func (c *Chain) MulMod(y, m *Int) *Chain {
+	if c.err == nil {
+		_, c.err = c.v.CheckedMulMod(&c.v, y, m)
+	}
+	return c
+}
+
+// Lsh sets the value to value<<n, failing with ErrOverflow if any set bit
+// is shifted out.
+func (c *Chain) Lsh(n uint) *Chain {
+	if c.err == nil {
+		if !c.v.IsZero() && (n >= 256 || uint(c.v.BitLen()) > 256-n) {
+			c.err = ErrOverflow
+		} else {
+			c.v.Lsh(&c.v, n)
+		}
+	}
+	return c
+}
+
+// Rsh sets the value to value>>n.
+func (c *Chain) Rsh(n uint) *Chain {
+	if c.err == nil {
+		c.v.Rsh(&c.v, n)
+	}
+	return c
+}
@@ -0,0 +1,40 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "testing"
+
+func TestChain(t *testing.T) {
+	max := new(Int).SetAllOne()
+	m := NewInt(1000)
+	for i, tc := range []struct {
+		c    *Chain
+		want *Int
+		err  error
+	}{
+		{NewChain(NewInt(7)).Mul(NewInt(6)).Add(NewInt(8)).Div(NewInt(5)), NewInt(10), nil},
+		{NewChain(NewInt(7)).Mul(NewInt(6)).AddMod(NewInt(999), m).MulMod(NewInt(3), m), NewInt(123), nil},
+		{NewChain(NewInt(1)).Lsh(255).Rsh(254).Sub(NewInt(2)).Mod(NewInt(3)), NewInt(0), nil},
+		{NewChain(max).Add(NewInt(1)).Sub(max), max, ErrOverflow},
+		{NewChain(NewInt(2)).Mul(NewInt(3)).Sub(NewInt(7)).Add(NewInt(100)), NewInt(6), ErrUnderflow},
+		{NewChain(max).Mul(NewInt(2)), max, ErrOverflow},
+		{NewChain(NewInt(5)).Div(new(Int)).Mul(max).Mul(max), NewInt(5), ErrDivByZero},
+		{NewChain(NewInt(5)).Mod(new(Int)), NewInt(5), ErrDivByZero},
+		{NewChain(NewInt(5)).AddMod(NewInt(1), new(Int)), NewInt(5), ErrDivByZero},
+		{NewChain(NewInt(5)).MulMod(NewInt(1), new(Int)), NewInt(5), ErrDivByZero},
+		{NewChain(NewInt(3)).Lsh(255), NewInt(3), ErrOverflow},
+		{NewChain(new(Int)).Lsh(1000), new(Int), nil},
+		{NewChain(NewInt(1)).Lsh(^uint(0)), NewInt(1), ErrOverflow},
+		{NewChain(NewInt(1)).Lsh(256), NewInt(1), ErrOverflow},
+	} {
+		got, err := tc.c.Value()
+		if err != tc.err || tc.c.Err() != tc.err {
+			t.Errorf("test %d: got error %v, want %v", i, err, tc.err)
+		}
+		if !got.Eq(tc.want) {
+			t.Errorf("test %d: got %x, want %x", i, got, tc.want)
+		}
+	}
+}
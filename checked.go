@@ -0,0 +1,82 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "errors"
+
+// Errors returned by the checked arithmetic methods. They correspond to the
+// Solidity 0.8 panic codes 0x11 (overflow/underflow) and 0x12 (division or
+// modulo by zero).
+var (
+	ErrOverflow  = errors.New("arithmetic overflow")
+	ErrUnderflow = errors.New("arithmetic underflow")
+	ErrDivByZero = errors.New("division by zero")
+)
+
+// CheckedAdd sets z to the sum x+y and returns z, or ErrOverflow if the sum
+// exceeds 256 bits. On error z is left unmodified.
+func (z *Int) CheckedAdd(x, y *Int) (*Int, error) {
+	var res Int
+	if _, overflow := res.AddOverflow(x, y); overflow {
+		return z, ErrOverflow
+	}
+	return z.Set(&res), nil
+}
+
+// CheckedSub sets z to the difference x-y and returns z, or ErrUnderflow if
+// y > x. On error z is left unmodified.
+func (z *Int) CheckedSub(x, y *Int) (*Int, error) {
+	var res Int
+	if _, underflow := res.SubOverflow(x, y); underflow {
+		return z, ErrUnderflow
+	}
+	return z.Set(&res), nil
+}
+
+// CheckedMul sets z to the product x*y and returns z, or ErrOverflow if the
+// product exceeds 256 bits. On error z is left unmodified.
+func (z *Int) CheckedMul(x, y *Int) (*Int, error) {
+	var res Int
+	if _, overflow := res.MulOverflow(x, y); overflow {
+		return z, ErrOverflow
+	}
+	return z.Set(&res), nil
+}
+
+// CheckedDiv sets z to the quotient x/y and returns z, or ErrDivByZero if
+// y == 0. On error z is left unmodified.
+func (z *Int) CheckedDiv(x, y *Int) (*Int, error) {
+	if y.IsZero() {
+		return z, ErrDivByZero
+	}
+	return z.Div(x, y), nil
+}
+
+// CheckedMod sets z to the modulus x%y and returns z, or ErrDivByZero if
+// y == 0. On error z is left unmodified.
+func (z *Int) CheckedMod(x, y *Int) (*Int, error) {
+	if y.IsZero() {
+		return z, ErrDivByZero
+	}
+	return z.Mod(x, y), nil
+}
+
+// CheckedAddMod sets z to (x+y) mod m and returns z, or ErrDivByZero if
+// m == 0. On error z is left unmodified.
+func (z *Int) CheckedAddMod(x, y, m *Int) (*Int, error) {
+	if m.IsZero() {
+		return z, ErrDivByZero
+	}
+	return z.AddMod(x, y, m), nil
+}
+
+// CheckedMulMod sets z to (x*y) mod m and returns z, or ErrDivByZero if
+// m == 0. On error z is left unmodified.
+func (z *Int) CheckedMulMod(x, y, m *Int) (*Int, error) {
+	if m.IsZero() {
+		return z, ErrDivByZero
+	}
+	return z.MulMod(x, y, m), nil
+}
@@ -0,0 +1,66 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCheckedOp(t *testing.T) {
+	proc := func(t *testing.T, op func(z, x, y *Int) (*Int, error), bigOp func(z, x, y *big.Int) *big.Int, wantErr error) {
+		for i := 0; i < len(binTestCases); i++ {
+			b1, _ := new(big.Int).SetString(binTestCases[i][0], 0)
+			b2, _ := new(big.Int).SetString(binTestCases[i][1], 0)
+			f1, _ := FromBig(b1)
+			f2, _ := FromBig(b2)
+
+			garbage := Int{1, 2, 3, 4}
+			z := garbage
+			res, err := op(&z, f1, f2)
+			if res != &z {
+				t.Fatalf("unexpected pointer returned")
+			}
+			var want *big.Int
+			if wantErr != ErrDivByZero || b2.Sign() != 0 {
+				want = bigOp(new(big.Int), b1, b2)
+			}
+			if want == nil || want.Sign() < 0 || want.BitLen() > 256 {
+				if err != wantErr {
+					t.Errorf("args: %s, %s: got error %v, want %v", binTestCases[i][0], binTestCases[i][1], err, wantErr)
+				}
+				if z != garbage {
+					t.Errorf("args: %s, %s: receiver modified on error", binTestCases[i][0], binTestCases[i][1])
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("args: %s, %s: unexpected error %v", binTestCases[i][0], binTestCases[i][1], err)
+			}
+			requireEq(t, want, &z, binTestCases[i][0]+", "+binTestCases[i][1])
+		}
+	}
+	t.Run("Add", func(t *testing.T) { proc(t, (*Int).CheckedAdd, (*big.Int).Add, ErrOverflow) })
+	t.Run("Sub", func(t *testing.T) { proc(t, (*Int).CheckedSub, (*big.Int).Sub, ErrUnderflow) })
+	t.Run("Mul", func(t *testing.T) { proc(t, (*Int).CheckedMul, (*big.Int).Mul, ErrOverflow) })
+	t.Run("Div", func(t *testing.T) { proc(t, (*Int).CheckedDiv, (*big.Int).Div, ErrDivByZero) })
+	t.Run("Mod", func(t *testing.T) { proc(t, (*Int).CheckedMod, (*big.Int).Mod, ErrDivByZero) })
+}
+
+func TestCheckedModOps(t *testing.T) {
+	x, y, m := NewInt(7), NewInt(9), NewInt(5)
+	if z, err := new(Int).CheckedAddMod(x, y, m); err != nil || !z.Eq(NewInt(1)) {
+		t.Errorf("CheckedAddMod: got %v, %v", z, err)
+	}
+	if z, err := new(Int).CheckedMulMod(x, y, m); err != nil || !z.Eq(NewInt(3)) {
+		t.Errorf("CheckedMulMod: got %v, %v", z, err)
+	}
+	if _, err := new(Int).CheckedAddMod(x, y, new(Int)); err != ErrDivByZero {
+		t.Errorf("CheckedAddMod: got %v, want ErrDivByZero", err)
+	}
+	if _, err := new(Int).CheckedMulMod(x, y, new(Int)); err != ErrDivByZero {
+		t.Errorf("CheckedMulMod: got %v, want ErrDivByZero", err)
+	}
+}
@@ -0,0 +1,45 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// clmul64 computes the 128-bit carry-less product of x and y.
+// The loop uses masks rather than branches, so the timing does not depend
+// on the operand values.
+func clmul64(x, y uint64) (hi, lo uint64) {
+	lo = x & -(y & 1)
+	for i := uint(1); i < 64; i++ {
+		mask := -((y >> i) & 1)
+		lo ^= (x << i) & mask
+		hi ^= (x >> (64 - i)) & mask
+	}
+	return hi, lo
+}
+
+// Clmul returns the 512-bit carry-less (polynomial over GF(2)) product of
+// x and y as the high and low 256-bit halves. On amd64 processors with the
+// PCLMULQDQ instruction it is computed in assembly.
+func Clmul(x, y *Int) (hi, lo Int) {
+	var res [8]uint64
+	if hasPCLMULQDQ {
+		clmulAsm(&res, x, y)
+	} else {
+		clmulGeneric(&res, x, y)
+	}
+	copy(lo[:], res[:4])
+	copy(hi[:], res[4:])
+	return hi, lo
+}
+
+// clmulGeneric sets res to the carry-less product of x and y, from 16
+// carry-less 64x64 products. res must be zero.
+func clmulGeneric(res *[8]uint64, x, y *Int) {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			h, l := clmul64(x[i], y[j])
+			res[i+j] ^= l
+			res[i+j+1] ^= h
+		}
+	}
+}
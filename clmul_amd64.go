@@ -0,0 +1,17 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// hasPCLMULQDQ reports whether the processor supports the carry-less
+// multiplication instruction used by clmulAsm.
+var hasPCLMULQDQ = cpuidPCLMULQDQ()
+
+// cpuidPCLMULQDQ reports the PCLMULQDQ feature bit of CPUID.
+func cpuidPCLMULQDQ() bool
+
+// clmulAsm sets res to the carry-less product of x and y, using PCLMULQDQ.
+//
+//go:noescape
+func clmulAsm(res *[8]uint64, x, y *Int)
@@ -0,0 +1,62 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+#include "textflag.h"
+
+// MUL128 sets (lo, hi) to the 256-bit carry-less product of the 128-bit
+// values a and b, clobbering t and m.
+#define MUL128(a, b, lo, hi, t, m) \
+	MOVOU a, lo                \
+	PCLMULQDQ $0x00, b, lo     \
+	MOVOU a, hi                \
+	PCLMULQDQ $0x11, b, hi     \
+	MOVOU a, m                 \
+	PCLMULQDQ $0x01, b, m      \
+	MOVOU a, t                 \
+	PCLMULQDQ $0x10, b, t      \
+	PXOR  t, m                 \
+	MOVOU m, t                 \
+	PSLLDQ $8, t               \
+	PXOR  t, lo                \
+	PSRLDQ $8, m               \
+	PXOR  m, hi
+
+// func cpuidPCLMULQDQ() bool
+TEXT ·cpuidPCLMULQDQ(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	SHRL $1, CX
+	ANDL $1, CX
+	MOVB CX, ret+0(FP)
+	RET
+
+// func clmulAsm(res *[8]uint64, x, y *Int)
+//
+// With x = x1*2**128 + x0 and y = y1*2**128 + y0, the product is
+// x1y1*2**256 + (x0y1 + x1y0)*2**128 + x0y0, each term a 128x128-bit
+// carry-less product.
+TEXT ·clmulAsm(SB), NOSPLIT, $0-24
+	MOVQ res+0(FP), DI
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DX
+	MOVOU 0(SI), X0
+	MOVOU 16(SI), X1
+	MOVOU 0(DX), X2
+	MOVOU 16(DX), X3
+
+	MUL128(X0, X2, X4, X5, X12, X13)
+	MUL128(X1, X3, X6, X7, X12, X13)
+	MUL128(X0, X3, X8, X9, X12, X13)
+	MUL128(X1, X2, X10, X11, X12, X13)
+	PXOR X10, X8
+	PXOR X11, X9
+	PXOR X8, X5
+	PXOR X9, X6
+
+	MOVOU X4, 0(DI)
+	MOVOU X5, 16(DI)
+	MOVOU X6, 32(DI)
+	MOVOU X7, 48(DI)
+	RET
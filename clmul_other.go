@@ -0,0 +1,14 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !amd64
+// +build !amd64
+
+package uint256
+
+const hasPCLMULQDQ = false
+
+func clmulAsm(res *[8]uint64, x, y *Int) {
+	clmulGeneric(res, x, y)
+}
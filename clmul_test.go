@@ -0,0 +1,73 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// clmulRef computes the carry-less product of x and y bit by bit.
+func clmulRef(x, y *big.Int) *big.Int {
+	res := new(big.Int)
+	for i := 0; i < y.BitLen(); i++ {
+		if y.Bit(i) == 1 {
+			res.Xor(res, new(big.Int).Lsh(x, uint(i)))
+		}
+	}
+	return res
+}
+
+func TestClmul(t *testing.T) {
+	check := func(b1, b2 *big.Int) {
+		f1, _ := FromBig(b1)
+		f2, _ := FromBig(b2)
+		want := clmulRef(b1, b2)
+		hi, lo := Clmul(f1, f2)
+		requireEq(t, new(big.Int).Rsh(want, 256), &hi, "Clmul hi")
+		requireEq(t, new(big.Int).Mod(want, bigtt256), &lo, "Clmul lo")
+	}
+	for i := 0; i < len(binTestCases); i++ {
+		b1, _ := new(big.Int).SetString(binTestCases[i][0], 0)
+		b2, _ := new(big.Int).SetString(binTestCases[i][1], 0)
+		check(b1, b2)
+	}
+	for i := 0; i < 200; i++ {
+		b1, _, _ := randNums()
+		b2, _, _ := randNums()
+		check(b1, b2)
+	}
+	// (x+1)^2 = x^2+1 over GF(2).
+	hi, lo := Clmul(NewInt(3), NewInt(3))
+	if !hi.IsZero() || !lo.Eq(NewInt(5)) {
+		t.Errorf("got %x %x, want 0 5", &hi, &lo)
+	}
+}
+
+// TestClmulAsm checks the assembly path, where available, against the
+// generic one.
+func TestClmulAsm(t *testing.T) {
+	if !hasPCLMULQDQ {
+		t.Skip("no PCLMULQDQ")
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randHighNums()
+		var want, got [8]uint64
+		clmulGeneric(&want, x, y)
+		clmulAsm(&got, x, y)
+		if got != want {
+			t.Fatalf("clmulAsm(%x, %x): got %x, want %x", x, y, got, want)
+		}
+	}
+}
+
+func BenchmarkClmul(b *testing.B) {
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	y, _ := FromHex("0xf6376770abd3a36b20394c5664afef1194c801c3f05e42566f085ed24d002bb0")
+	for i := 0; i < b.N; i++ {
+		Clmul(x, y)
+	}
+}
@@ -0,0 +1,239 @@
+// Code generated by u256gen -m 0xffffffff00000001 -pkg goldilocks. DO NOT EDIT.
+
+package goldilocks
+
+import (
+	"math/bits"
+
+	"github.com/holiman/uint256"
+)
+
+const (
+	m0   = 0xffffffff00000001
+	m1   = 0x0000000000000000
+	m2   = 0x0000000000000000
+	m3   = 0x0000000000000000
+	mInv = 0xfffffffeffffffff // -m**-1 mod 2**64
+)
+
+// Modulus is the modulus of the arithmetic in this file.
+var Modulus = uint256.Int{m0, m1, m2, m3}
+
+var (
+	rModM = uint256.Int{0x00000000ffffffff, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000} // R mod m, with R = 2**256
+	r2    = uint256.Int{0xfffffffe00000001, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000} // R**2 mod m
+)
+
+// AddMod sets z to x+y mod m, for x, y < m, and returns z.
+func AddMod(z, x, y *uint256.Int) *uint256.Int {
+	var t0, t1, t2, t3, carry uint64
+	t0, carry = bits.Add64(x[0], y[0], 0)
+	t1, carry = bits.Add64(x[1], y[1], carry)
+	t2, carry = bits.Add64(x[2], y[2], carry)
+	t3, carry = bits.Add64(x[3], y[3], carry)
+	var s0, s1, s2, s3, borrow uint64
+	s0, borrow = bits.Sub64(t0, m0, 0)
+	s1, borrow = bits.Sub64(t1, m1, borrow)
+	s2, borrow = bits.Sub64(t2, m2, borrow)
+	s3, borrow = bits.Sub64(t3, m3, borrow)
+	// Keep t if t < m.
+	mask := -(borrow &^ carry)
+	z[0] = s0 ^ ((t0 ^ s0) & mask)
+	z[1] = s1 ^ ((t1 ^ s1) & mask)
+	z[2] = s2 ^ ((t2 ^ s2) & mask)
+	z[3] = s3 ^ ((t3 ^ s3) & mask)
+	return z
+}
+
+// SubMod sets z to x-y mod m, for x, y < m, and returns z.
+func SubMod(z, x, y *uint256.Int) *uint256.Int {
+	var t0, t1, t2, t3, borrow, carry uint64
+	t0, borrow = bits.Sub64(x[0], y[0], 0)
+	t1, borrow = bits.Sub64(x[1], y[1], borrow)
+	t2, borrow = bits.Sub64(x[2], y[2], borrow)
+	t3, borrow = bits.Sub64(x[3], y[3], borrow)
+	// Add m back if the subtraction borrowed.
+	mask := -borrow
+	z[0], carry = bits.Add64(t0, m0&mask, 0)
+	z[1], carry = bits.Add64(t1, m1&mask, carry)
+	z[2], carry = bits.Add64(t2, m2&mask, carry)
+	z[3], _ = bits.Add64(t3, m3&mask, carry)
+	return z
+}
+
+// montMul sets z to x*y/R mod m, for x, y < m.
+func montMul(z, x, y *uint256.Int) {
+	var t0, t1, t2, t3, t4, t5, c, hi, lo, cc, mm uint64
+
+	// Row 0: t += x*y[0], then t = (t + mm*m) / 2**64.
+	hi, lo = bits.Mul64(x[0], y[0])
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x[1], y[0])
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x[2], y[0])
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x[3], y[0])
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, t5 = c, 0
+	mm = t0 * mInv
+	hi, lo = bits.Mul64(mm, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	t0, c = bits.Add64(t1, c, 0)
+	t1, c = bits.Add64(t2, c, 0)
+	t2, c = bits.Add64(t3, c, 0)
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Row 1: t += x*y[1], then t = (t + mm*m) / 2**64.
+	hi, lo = bits.Mul64(x[0], y[1])
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x[1], y[1])
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x[2], y[1])
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x[3], y[1])
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, t5 = bits.Add64(t4, c, 0)
+	mm = t0 * mInv
+	hi, lo = bits.Mul64(mm, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	t0, c = bits.Add64(t1, c, 0)
+	t1, c = bits.Add64(t2, c, 0)
+	t2, c = bits.Add64(t3, c, 0)
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Row 2: t += x*y[2], then t = (t + mm*m) / 2**64.
+	hi, lo = bits.Mul64(x[0], y[2])
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x[1], y[2])
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x[2], y[2])
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x[3], y[2])
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, t5 = bits.Add64(t4, c, 0)
+	mm = t0 * mInv
+	hi, lo = bits.Mul64(mm, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	t0, c = bits.Add64(t1, c, 0)
+	t1, c = bits.Add64(t2, c, 0)
+	t2, c = bits.Add64(t3, c, 0)
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Row 3: t += x*y[3], then t = (t + mm*m) / 2**64.
+	hi, lo = bits.Mul64(x[0], y[3])
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x[1], y[3])
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x[2], y[3])
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x[3], y[3])
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, t5 = bits.Add64(t4, c, 0)
+	mm = t0 * mInv
+	hi, lo = bits.Mul64(mm, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	t0, c = bits.Add64(t1, c, 0)
+	t1, c = bits.Add64(t2, c, 0)
+	t2, c = bits.Add64(t3, c, 0)
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// The result t < 2m.
+	var s0, s1, s2, s3, borrow uint64
+	s0, borrow = bits.Sub64(t0, m0, 0)
+	s1, borrow = bits.Sub64(t1, m1, borrow)
+	s2, borrow = bits.Sub64(t2, m2, borrow)
+	s3, borrow = bits.Sub64(t3, m3, borrow)
+	// Keep t if t < m.
+	mask := -(borrow &^ t4)
+	z[0] = s0 ^ ((t0 ^ s0) & mask)
+	z[1] = s1 ^ ((t1 ^ s1) & mask)
+	z[2] = s2 ^ ((t2 ^ s2) & mask)
+	z[3] = s3 ^ ((t3 ^ s3) & mask)
+}
+
+// MulMod sets z to x*y mod m, for x, y < m, and returns z.
+func MulMod(z, x, y *uint256.Int) *uint256.Int {
+	montMul(z, x, y)
+	montMul(z, z, &r2)
+	return z
+}
+
+// ExpMod sets z to base**exp mod m, for base < m, and returns z, using
+// 4-bit fixed windows.
+func ExpMod(z, base, exp *uint256.Int) *uint256.Int {
+	// table[i] = base**i, in Montgomery form.
+	var table [16]uint256.Int
+	table[0] = rModM
+	montMul(&table[1], base, &r2)
+	for i := 2; i < len(table); i++ {
+		montMul(&table[i], &table[i-1], &table[1])
+	}
+	res := rModM
+	for w := (exp.BitLen()+3)/4 - 1; w >= 0; w-- {
+		montMul(&res, &res, &res)
+		montMul(&res, &res, &res)
+		montMul(&res, &res, &res)
+		montMul(&res, &res, &res)
+		if digit := (exp[w/16] >> uint(w%16*4)) & 0xf; digit != 0 {
+			montMul(&res, &res, &table[digit])
+		}
+	}
+	montMul(z, &res, &uint256.Int{1})
+	return z
+}
@@ -0,0 +1,47 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package goldilocks
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func randReduced(t testing.TB) *uint256.Int {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	x := new(uint256.Int).SetBytes(b)
+	return x.Mod(x, &Modulus)
+}
+
+func TestArithmetic(t *testing.T) {
+	bm := Modulus.ToBig()
+	var pm1 uint256.Int
+	pm1.SubUint64(&Modulus, 1)
+	for i := 0; i < 1000; i++ {
+		x, y := randReduced(t), randReduced(t)
+		if i == 0 {
+			x, y = &pm1, &pm1
+		}
+		bx, by := x.ToBig(), y.ToBig()
+		check := func(op string, got *uint256.Int, want *big.Int) {
+			t.Helper()
+			if got.ToBig().Cmp(want) != 0 {
+				t.Fatalf("%v(%x, %x): got %x, want %x", op, x, y, got, want)
+			}
+		}
+		check("AddMod", AddMod(new(uint256.Int), x, y), new(big.Int).Mod(new(big.Int).Add(bx, by), bm))
+		check("SubMod", SubMod(new(uint256.Int), x, y), new(big.Int).Mod(new(big.Int).Sub(bx, by), bm))
+		check("MulMod", MulMod(new(uint256.Int), x, y), new(big.Int).Mod(new(big.Int).Mul(bx, by), bm))
+		if i%20 == 0 {
+			check("ExpMod", ExpMod(new(uint256.Int), x, y), new(big.Int).Exp(bx, by, bm))
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// The u256gen command generates modular arithmetic for one hardcoded odd
+// modulus.
+//
+// Usage:
+//
+//	u256gen -m modulus [-pkg name] [-o file]
+//
+// The modulus is evaluated with uint256.Eval, so expressions such as
+// "2 ** 255 - 19" are accepted. The generated file defines Modulus and the
+// functions AddMod, SubMod, MulMod and ExpMod, which take operands already
+// reduced modulo Modulus. Products use Montgomery multiplication, fully
+// unrolled with the limbs of the modulus as constants, so that limbs equal
+// to zero cost nothing. Without -o, the file is written to standard output.
+package main
+
+//go:generate go run . -m 0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47 -pkg example -o internal/example/example.go
+//go:generate go run . -m "2 ** 64 - 2 ** 32 + 1" -pkg goldilocks -o internal/goldilocks/goldilocks.go
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/holiman/uint256"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "u256gen:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("u256gen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	modulus := fs.String("m", "", "the odd `modulus`, as a uint256.Eval expression")
+	pkg := fs.String("pkg", "main", "package `name` of the generated file")
+	out := fs.String("o", "", "output `file`; standard output if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *modulus == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	m, err := uint256.Eval(*modulus, nil)
+	if err != nil {
+		return err
+	}
+	src, err := generate(m, *pkg)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(*out, src, 0644)
+}
+
+// generate returns the formatted source for arithmetic modulo m.
+func generate(m *uint256.Int, pkg string) ([]byte, error) {
+	if m[0]&1 == 0 || m.Eq(uint256.NewInt(1)) {
+		return nil, errors.New("modulus must be odd and greater than 1")
+	}
+	// Newton iteration for m**-1 mod 2**64, as in uint256.MontContext.
+	inv := m[0]
+	for i := 0; i < 6; i++ {
+		inv *= 2 - m[0]*inv
+	}
+	var r, r2 uint256.Int
+	r.Neg(m).Mod(&r, m) // R mod m, R = 2**256
+	r2.MulMod(&r, &r, m)
+
+	g := &generator{m: m}
+	g.printf("// Code generated by u256gen -m %s -pkg %s. DO NOT EDIT.\n\n", m.Hex(), pkg)
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n\"math/bits\"\n\n\"github.com/holiman/uint256\"\n)\n\n")
+	g.printf("const (\n")
+	for i, w := range m {
+		g.printf("m%d = %#016x\n", i, w)
+	}
+	g.printf("mInv = %#016x // -m**-1 mod 2**64\n)\n\n", -inv)
+	g.printf("// Modulus is the modulus of the arithmetic in this file.\n")
+	g.printf("var Modulus = uint256.Int{m0, m1, m2, m3}\n\n")
+	g.printf("var (\n")
+	g.printf("rModM = %s // R mod m, with R = 2**256\n", literal(&r))
+	g.printf("r2 = %s // R**2 mod m\n)\n\n", literal(&r2))
+	g.addMod()
+	g.subMod()
+	g.montMul()
+	g.buf.WriteString(tail)
+	return format.Source(g.buf.Bytes())
+}
+
+func literal(x *uint256.Int) string {
+	return fmt.Sprintf("uint256.Int{%#016x, %#016x, %#016x, %#016x}", x[0], x[1], x[2], x[3])
+}
+
+type generator struct {
+	m   *uint256.Int
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// reduceOnce emits the branch-free subtraction of m from the value in
+// t0..t3 plus the carry word top, which must be below 2m, into z.
+func (g *generator) reduceOnce(top string) {
+	g.printf("var s0, s1, s2, s3, borrow uint64\n")
+	g.printf("s0, borrow = bits.Sub64(t0, m0, 0)\n")
+	for i := 1; i < 4; i++ {
+		g.printf("s%d, borrow = bits.Sub64(t%d, m%d, borrow)\n", i, i, i)
+	}
+	g.printf("// Keep t if t < m.\nmask := -(borrow &^ %s)\n", top)
+	for i := 0; i < 4; i++ {
+		g.printf("z[%d] = s%d ^ ((t%d ^ s%d) & mask)\n", i, i, i, i)
+	}
+}
+
+func (g *generator) addMod() {
+	g.printf("// AddMod sets z to x+y mod m, for x, y < m, and returns z.\n")
+	g.printf("func AddMod(z, x, y *uint256.Int) *uint256.Int {\n")
+	g.printf("var t0, t1, t2, t3, carry uint64\n")
+	g.printf("t0, carry = bits.Add64(x[0], y[0], 0)\n")
+	for i := 1; i < 4; i++ {
+		g.printf("t%d, carry = bits.Add64(x[%d], y[%d], carry)\n", i, i, i)
+	}
+	g.reduceOnce("carry")
+	g.printf("return z\n}\n\n")
+}
+
+func (g *generator) subMod() {
+	g.printf("// SubMod sets z to x-y mod m, for x, y < m, and returns z.\n")
+	g.printf("func SubMod(z, x, y *uint256.Int) *uint256.Int {\n")
+	g.printf("var t0, t1, t2, t3, borrow, carry uint64\n")
+	g.printf("t0, borrow = bits.Sub64(x[0], y[0], 0)\n")
+	for i := 1; i < 4; i++ {
+		g.printf("t%d, borrow = bits.Sub64(x[%d], y[%d], borrow)\n", i, i, i)
+	}
+	g.printf("// Add m back if the subtraction borrowed.\nmask := -borrow\n")
+	g.printf("z[0], carry = bits.Add64(t0, m0&mask, 0)\n")
+	for i := 1; i < 3; i++ {
+		g.printf("z[%d], carry = bits.Add64(t%d, m%d&mask, carry)\n", i, i, i)
+	}
+	g.printf("z[3], _ = bits.Add64(t3, m3&mask, carry)\n")
+	g.printf("return z\n}\n\n")
+}
+
+// montMul emits the CIOS Montgomery product, unrolled.
+func (g *generator) montMul() {
+	g.printf("// montMul sets z to x*y/R mod m, for x, y < m.\n")
+	g.printf("func montMul(z, x, y *uint256.Int) {\n")
+	g.printf("var t0, t1, t2, t3, t4, t5, c, hi, lo, cc, mm uint64\n")
+	for i := 0; i < 4; i++ {
+		g.printf("\n// Row %d: t += x*y[%d], then t = (t + mm*m) / 2**64.\n", i, i)
+		for j := 0; j < 4; j++ {
+			g.printf("hi, lo = bits.Mul64(x[%d], y[%d])\n", j, i)
+			if i > 0 {
+				g.printf("lo, cc = bits.Add64(lo, t%d, 0)\nhi += cc\n", j)
+			}
+			if j > 0 {
+				g.printf("lo, cc = bits.Add64(lo, c, 0)\nhi += cc\n")
+			}
+			g.printf("t%d, c = lo, hi\n", j)
+		}
+		if i > 0 {
+			g.printf("t4, t5 = bits.Add64(t4, c, 0)\n")
+		} else {
+			g.printf("t4, t5 = c, 0\n")
+		}
+		g.printf("mm = t0 * mInv\n")
+		g.printf("hi, lo = bits.Mul64(mm, m0)\n_, cc = bits.Add64(lo, t0, 0)\nc = hi + cc\n")
+		for j := 1; j < 4; j++ {
+			if g.m[j] == 0 {
+				g.printf("t%d, c = bits.Add64(t%d, c, 0)\n", j-1, j)
+				continue
+			}
+			g.printf("hi, lo = bits.Mul64(mm, m%d)\n", j)
+			g.printf("lo, cc = bits.Add64(lo, t%d, 0)\nhi += cc\n", j)
+			g.printf("lo, cc = bits.Add64(lo, c, 0)\nhi += cc\n")
+			g.printf("t%d, c = lo, hi\n", j-1)
+		}
+		g.printf("t3, cc = bits.Add64(t4, c, 0)\nt4 = t5 + cc\n")
+	}
+	g.printf("\n// The result t < 2m.\n")
+	g.reduceOnce("t4")
+	g.printf("}\n\n")
+}
+
+const tail = `// MulMod sets z to x*y mod m, for x, y < m, and returns z.
+func MulMod(z, x, y *uint256.Int) *uint256.Int {
+	montMul(z, x, y)
+	montMul(z, z, &r2)
+	return z
+}
+
+// ExpMod sets z to base**exp mod m, for base < m, and returns z, using
+// 4-bit fixed windows.
+func ExpMod(z, base, exp *uint256.Int) *uint256.Int {
+	// table[i] = base**i, in Montgomery form.
+	var table [16]uint256.Int
+	table[0] = rModM
+	montMul(&table[1], base, &r2)
+	for i := 2; i < len(table); i++ {
+		montMul(&table[i], &table[i-1], &table[1])
+	}
+	res := rModM
+	for w := (exp.BitLen()+3)/4 - 1; w >= 0; w-- {
+		montMul(&res, &res, &res)
+		montMul(&res, &res, &res)
+		montMul(&res, &res, &res)
+		montMul(&res, &res, &res)
+		if digit := (exp[w/16] >> uint(w%16*4)) & 0xf; digit != 0 {
+			montMul(&res, &res, &table[digit])
+		}
+	}
+	montMul(z, &res, &uint256.Int{1})
+	return z
+}
+`
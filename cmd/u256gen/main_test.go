@@ -0,0 +1,48 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestGenerated checks that the checked-in generated packages are up to
+// date; run go generate if it fails.
+func TestGenerated(t *testing.T) {
+	for _, tc := range []struct{ modulus, pkg, file string }{
+		{"0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47", "example", "internal/example/example.go"},
+		{"2 ** 64 - 2 ** 32 + 1", "goldilocks", "internal/goldilocks/goldilocks.go"},
+	} {
+		var out bytes.Buffer
+		if err := run([]string{"-m", tc.modulus, "-pkg", tc.pkg}, &out, ioutil.Discard); err != nil {
+			t.Fatal(err)
+		}
+		want, err := ioutil.ReadFile(tc.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("%s is out of date", tc.file)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"-m", "0x10"},
+		{"-m", "1"},
+		{"-m", "0"},
+		{"-m", "1 +"},
+		{"-m", "7", "extra"},
+	} {
+		if err := run(args, ioutil.Discard, ioutil.Discard); err == nil {
+			t.Errorf("run(%q): got no error", strings.Join(args, " "))
+		}
+	}
+}
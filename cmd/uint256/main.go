@@ -0,0 +1,107 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// The uint256 command is a calculator for 256-bit unsigned integers.
+//
+// Usage:
+//
+//	uint256 [-o hex|dec|bin|all] [-var name=value]... [expression]
+//
+// The expression is evaluated with uint256.Eval, so it accepts Go integer
+// literals (decimal, 0x, 0o and 0b), the operators + - * / % ** << >>,
+// parentheses and modular contexts such as "a ** b mod m". Without an
+// expression, each line of standard input is evaluated in turn. Examples:
+//
+//	uint256 -o dec 0xff                   # base conversion
+//	uint256 '2 ** 256 - 1'                # wraps like uint256.Int
+//	uint256 -var p=0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f '3 ** (p - 2) mod p'
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+// varFlag collects -var name=value flags.
+type varFlag map[string]*uint256.Int
+
+func (v varFlag) String() string { return "" }
+
+func (v varFlag) Set(s string) error {
+	eq := strings.IndexByte(s, '=')
+	if eq <= 0 {
+		return errors.New("expected name=value")
+	}
+	val, err := uint256.Eval(s[eq+1:], v)
+	if err != nil {
+		return err
+	}
+	v[s[:eq]] = val
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "uint256:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("uint256", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	vars := varFlag{}
+	format := fs.String("o", "hex", "output format: hex, dec, bin or all")
+	fs.Var(vars, "var", "define a variable as `name=value`; may be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch *format {
+	case "hex", "dec", "bin", "all":
+	default:
+		return fmt.Errorf("unknown output format %q", *format)
+	}
+	if fs.NArg() > 0 {
+		return eval(strings.Join(fs.Args(), " "), vars, *format, stdout)
+	}
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if err := eval(line, vars, *format, stdout); err != nil {
+			fmt.Fprintln(stderr, err)
+		}
+	}
+	return sc.Err()
+}
+
+// eval evaluates expr and prints the result in the given format.
+func eval(expr string, vars map[string]*uint256.Int, format string, w io.Writer) error {
+	z, err := uint256.Eval(expr, vars)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "hex":
+		_, err = fmt.Fprintln(w, z.Hex())
+	case "dec":
+		_, err = fmt.Fprintln(w, z.ToBig().String())
+	case "bin":
+		_, err = fmt.Fprintln(w, "0b"+z.ToBig().Text(2))
+	case "all":
+		_, err = fmt.Fprintf(w, "hex %s\ndec %s\nbin 0b%s\n", z.Hex(), z.ToBig().String(), z.ToBig().Text(2))
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		args  []string
+		stdin string
+		want  string
+		fails bool
+	}{
+		{args: []string{"1", "+", "2"}, want: "0x3\n"},
+		{args: []string{"-o", "dec", "0xff"}, want: "255\n"},
+		{args: []string{"-o", "bin", "5"}, want: "0b101\n"},
+		{args: []string{"-o", "all", "10"}, want: "hex 0xa\ndec 10\nbin 0b1010\n"},
+		{args: []string{"0 - 1"}, want: "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff\n"},
+		{
+			args: []string{"-var", "p=0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", "-var", "x=p-1", "x + ((3 ** (p - 2) mod p) * 3 mod p)"},
+			want: "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f\n",
+		},
+		{args: []string{"-o", "dec"}, stdin: "1 << 8\n\n7 / 0\n2 ** 10\n", want: "256\n1024\n"},
+		{args: []string{"1 +"}, fails: true},
+		{args: []string{"-o", "oct", "1"}, fails: true},
+		{args: []string{"-var", "x", "1"}, fails: true},
+	} {
+		var stdout, stderr bytes.Buffer
+		err := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+		if (err != nil) != tc.fails {
+			t.Errorf("%q: got error %v", tc.args, err)
+		}
+		if got := stdout.String(); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
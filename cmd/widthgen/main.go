@@ -0,0 +1,439 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// The widthgen command generates a fixed-width unsigned integer type for
+// package uint256.
+//
+// Usage:
+//
+//	widthgen -bits n [-pkg name] [-o file]
+//
+// The generated type UintN is an array of 64-bit words in the little-endian
+// limb order of uint256.Int. Its arithmetic wraps around modulo 2**n, apart
+// from the modular methods AddMod, MulMod and ExpMod, which work on full
+// precision intermediates. It is built on the word-slice helpers of package
+// uint256, so the file must be
+// generated into that package. The width must be a multiple of 32 between
+// 64 and 512. Without -o, the file is written to standard output.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+	"text/template"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "widthgen:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("widthgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	n := fs.Uint("bits", 0, "the `width` of the type in bits")
+	pkg := fs.String("pkg", "uint256", "package `name` of the generated file")
+	out := fs.String("o", "", "output `file`; standard output if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *n == 0 || fs.NArg() > 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	src, err := generate(*n, *pkg)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(*out, src, 0644)
+}
+
+// params holds the values substituted into the template.
+type params struct {
+	Pkg     string
+	Name    string
+	Bits    uint
+	Words   uint
+	Bytes   uint
+	Top     uint   // index of the top word
+	Partial bool   // whether the top word is only partly used
+	TopMask string // mask of the used bits in the top word, if Partial
+}
+
+// generate returns the formatted source of the type for width n.
+func generate(n uint, pkg string) ([]byte, error) {
+	// The helpers in package uint256 support up to 16 words, twice the
+	// widest type, for the products reduced by MulMod.
+	if n%32 != 0 || n < 64 || n > 512 {
+		return nil, errors.New("width must be a multiple of 32 between 64 and 512")
+	}
+	p := params{
+		Pkg:     pkg,
+		Name:    fmt.Sprintf("Uint%d", n),
+		Bits:    n,
+		Words:   (n + 63) / 64,
+		Bytes:   n / 8,
+		Top:     (n+63)/64 - 1,
+		Partial: n%64 != 0,
+	}
+	if p.Partial {
+		p.TopMask = fmt.Sprintf("%#x", uint64(1)<<(n%64)-1)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("width").Parse(`// Code generated by widthgen -bits {{.Bits}}. DO NOT EDIT.
+
+package {{.Pkg}}
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// {{.Name}} is a {{.Bits}}-bit unsigned integer, in the little-endian limb order
+// of Int. Arithmetic wraps around modulo 2**{{.Bits}}.
+type {{.Name}} [{{.Words}}]uint64
+
+// Set sets z to x and returns z.
+func (z *{{.Name}}) Set(x *{{.Name}}) *{{.Name}} {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to v and returns z.
+func (z *{{.Name}}) SetUint64(v uint64) *{{.Name}} {
+	*z = {{.Name}}{v}
+	return z
+}
+
+// SetInt sets z to x mod 2**{{.Bits}}, and returns z and whether x did not fit.
+func (z *{{.Name}}) SetInt(x *Int) (*{{.Name}}, bool) {
+	*z = {{.Name}}{}
+	overflow := false
+	for i, w := range x {
+		if i < len(z) {
+			z[i] = w
+		} else if w != 0 {
+			overflow = true
+		}
+	}
+{{- if .Partial}}
+	if z[{{.Top}}]&^{{.TopMask}} != 0 {
+		overflow = true
+	}
+	z[{{.Top}}] &= {{.TopMask}}
+{{- end}}
+	return z, overflow
+}
+
+// ToInt returns z mod 2**256 as an Int, and whether z did not fit.
+func (z *{{.Name}}) ToInt() (*Int, bool) {
+	var x Int
+	overflow := false
+	for i, w := range z {
+		if i < len(x) {
+			x[i] = w
+		} else if w != 0 {
+			overflow = true
+		}
+	}
+	return &x, overflow
+}
+
+// SetBytes interprets b as the bytes of a big-endian unsigned integer, sets
+// z to that value mod 2**{{.Bits}}, and returns z.
+func (z *{{.Name}}) SetBytes(b []byte) *{{.Name}} {
+	if len(b) > {{.Bytes}} {
+		b = b[len(b)-{{.Bytes}}:]
+	}
+	*z = {{.Name}}{}
+	for i, c := range b {
+		pos := uint(len(b) - 1 - i)
+		z[pos/8] |= uint64(c) << (pos % 8 * 8)
+	}
+	return z
+}
+
+// Bytes returns the value of z as a {{.Bytes}}-byte big-endian array.
+func (z *{{.Name}}) Bytes() [{{.Bytes}}]byte {
+	var b [{{.Bytes}}]byte
+	for i := range b {
+		pos := uint(len(b) - 1 - i)
+		b[i] = byte(z[pos/8] >> (pos % 8 * 8))
+	}
+	return b
+}
+
+// SetFromBig sets z to b mod 2**{{.Bits}}, and returns whether b did not fit.
+// A negative b is converted as its two's complement.
+func (z *{{.Name}}) SetFromBig(b *big.Int) bool {
+	z.SetBytes(new(big.Int).And(b, {{.Name | printf "big%sMask"}}).Bytes())
+	return b.Sign() < 0 || b.BitLen() > {{.Bits}}
+}
+
+var {{.Name | printf "big%sMask"}} = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), {{.Bits}}), big.NewInt(1))
+
+// ToBig returns a big.Int version of z.
+func (z *{{.Name}}) ToBig() *big.Int {
+	b := new(big.Int)
+	for i := len(z) - 1; i >= 0; i-- {
+		b.Lsh(b, 64)
+		b.Or(b, new(big.Int).SetUint64(z[i]))
+	}
+	return b
+}
+
+// String returns the hex encoding of z.
+func (z *{{.Name}}) String() string {
+	return "0x" + z.ToBig().Text(16)
+}
+
+// IsZero returns true if z == 0.
+func (z *{{.Name}}) IsZero() bool {
+	return *z == {{.Name}}{}
+}
+
+// Eq returns true if z == x.
+func (z *{{.Name}}) Eq(x *{{.Name}}) bool {
+	return *z == *x
+}
+
+// Lt returns true if z < x.
+func (z *{{.Name}}) Lt(x *{{.Name}}) bool {
+	var borrow uint64
+	for i := range z {
+		_, borrow = bits.Sub64(z[i], x[i], borrow)
+	}
+	return borrow != 0
+}
+
+// Cmp compares z and x and returns -1 if z < x, 0 if z == x and +1 if z > x.
+func (z *{{.Name}}) Cmp(x *{{.Name}}) int {
+	switch {
+	case z.Lt(x):
+		return -1
+	case *z == *x:
+		return 0
+	}
+	return 1
+}
+
+// BitLen returns the number of bits required to represent z.
+func (z *{{.Name}}) BitLen() int {
+	return bitLenWords(z[:])
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *{{.Name}}) Add(x, y *{{.Name}}) *{{.Name}} {
+	z.AddOverflow(x, y)
+	return z
+}
+
+// AddOverflow sets z to the sum x+y, and returns z and whether overflow
+// occurred.
+func (z *{{.Name}}) AddOverflow(x, y *{{.Name}}) (*{{.Name}}, bool) {
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+{{- if .Partial}}
+	overflow := z[{{.Top}}]&^{{.TopMask}} != 0
+	z[{{.Top}}] &= {{.TopMask}}
+	return z, overflow
+{{- else}}
+	return z, carry != 0
+{{- end}}
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *{{.Name}}) Sub(x, y *{{.Name}}) *{{.Name}} {
+	z.SubOverflow(x, y)
+	return z
+}
+
+// SubOverflow sets z to the difference x-y, and returns z and whether the
+// operation underflowed.
+func (z *{{.Name}}) SubOverflow(x, y *{{.Name}}) (*{{.Name}}, bool) {
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+{{- if .Partial}}
+	z[{{.Top}}] &= {{.TopMask}}
+{{- end}}
+	return z, borrow != 0
+}
+
+// Mul sets z to the product x*y and returns z.
+func (z *{{.Name}}) Mul(x, y *{{.Name}}) *{{.Name}} {
+	z.MulOverflow(x, y)
+	return z
+}
+
+// MulOverflow sets z to the product x*y, and returns z and whether overflow
+// occurred.
+func (z *{{.Name}}) MulOverflow(x, y *{{.Name}}) (*{{.Name}}, bool) {
+	var p [2 * {{.Words}}]uint64
+	mulWords(p[:], x[:], y[:])
+	copy(z[:], p[:{{.Words}}])
+	overflow := false
+	for _, w := range p[{{.Words}}:] {
+		overflow = overflow || w != 0
+	}
+{{- if .Partial}}
+	overflow = overflow || z[{{.Top}}]&^{{.TopMask}} != 0
+	z[{{.Top}}] &= {{.TopMask}}
+{{- end}}
+	return z, overflow
+}
+
+// Div sets z to the quotient x/y and returns z.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *{{.Name}}) Div(x, y *{{.Name}}) *{{.Name}} {
+	if y.IsZero() {
+		*z = {{.Name}}{}
+		return z
+	}
+	var q, r {{.Name}}
+	udivremWords(q[:], r[:], x[:], y[:])
+	*z = q
+	return z
+}
+
+// Mod sets z to the remainder x%y and returns z.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *{{.Name}}) Mod(x, y *{{.Name}}) *{{.Name}} {
+	if y.IsZero() {
+		*z = {{.Name}}{}
+		return z
+	}
+	var q, r {{.Name}}
+	udivremWords(q[:], r[:], x[:], y[:])
+	*z = r
+	return z
+}
+
+// AddMod sets z to x+y mod m and returns z. The sum does not wrap.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *{{.Name}}) AddMod(x, y, m *{{.Name}}) *{{.Name}} {
+	if m.IsZero() {
+		*z = {{.Name}}{}
+		return z
+	}
+	var s, q [{{.Words}} + 1]uint64
+	var carry uint64
+	for i := range z {
+		s[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	s[{{.Words}}] = carry
+	var r {{.Name}}
+	udivremWords(q[:], r[:], s[:], m[:])
+	*z = r
+	return z
+}
+
+// MulMod sets z to x*y mod m and returns z. The product does not wrap.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *{{.Name}}) MulMod(x, y, m *{{.Name}}) *{{.Name}} {
+	if m.IsZero() {
+		*z = {{.Name}}{}
+		return z
+	}
+	var p, q [2 * {{.Words}}]uint64
+	mulWords(p[:], x[:], y[:])
+	var r {{.Name}}
+	udivremWords(q[:], r[:], p[:], m[:])
+	*z = r
+	return z
+}
+
+// ExpMod sets z to base**exp mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *{{.Name}}) ExpMod(base, exp, m *{{.Name}}) *{{.Name}} {
+	if m.IsZero() {
+		*z = {{.Name}}{}
+		return z
+	}
+	var res, b {{.Name}}
+	res.Mod(res.SetUint64(1), m)
+	b.Mod(base, m)
+	for i := exp.BitLen() - 1; i >= 0; i-- {
+		res.MulMod(&res, &res, m)
+		if exp[i/64]>>uint(i%64)&1 != 0 {
+			res.MulMod(&res, &b, m)
+		}
+	}
+	*z = res
+	return z
+}
+
+// Lsh sets z to x << n and returns z.
+func (z *{{.Name}}) Lsh(x *{{.Name}}, n uint) *{{.Name}} {
+	lshWords(z[:], x[:], n)
+{{- if .Partial}}
+	z[{{.Top}}] &= {{.TopMask}}
+{{- end}}
+	return z
+}
+
+// Rsh sets z to x >> n and returns z.
+func (z *{{.Name}}) Rsh(x *{{.Name}}, n uint) *{{.Name}} {
+	rshWords(z[:], x[:], n)
+	return z
+}
+
+// And sets z to x & y and returns z.
+func (z *{{.Name}}) And(x, y *{{.Name}}) *{{.Name}} {
+	for i := range z {
+		z[i] = x[i] & y[i]
+	}
+	return z
+}
+
+// Or sets z to x | y and returns z.
+func (z *{{.Name}}) Or(x, y *{{.Name}}) *{{.Name}} {
+	for i := range z {
+		z[i] = x[i] | y[i]
+	}
+	return z
+}
+
+// Xor sets z to x ^ y and returns z.
+func (z *{{.Name}}) Xor(x, y *{{.Name}}) *{{.Name}} {
+	for i := range z {
+		z[i] = x[i] ^ y[i]
+	}
+	return z
+}
+
+// Not sets z to ^x and returns z.
+func (z *{{.Name}}) Not(x *{{.Name}}) *{{.Name}} {
+	for i := range z {
+		z[i] = ^x[i]
+	}
+{{- if .Partial}}
+	z[{{.Top}}] &= {{.TopMask}}
+{{- end}}
+	return z
+}
+`))
@@ -0,0 +1,48 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestGenerated checks that the checked-in generated types are up to date;
+// run go generate in the repository root if it fails.
+func TestGenerated(t *testing.T) {
+	for _, n := range []int{160, 192, 320, 384} {
+		var out bytes.Buffer
+		if err := run([]string{"-bits", fmt.Sprint(n)}, &out, ioutil.Discard); err != nil {
+			t.Fatal(err)
+		}
+		file := fmt.Sprintf("../../uint%d.go", n)
+		want, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("%s is out of date", file)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"-bits", "0"},
+		{"-bits", "32"},
+		{"-bits", "100"},
+		{"-bits", "544"},
+		{"-bits", "-1"},
+		{"-bits", "160", "extra"},
+	} {
+		if err := run(args, ioutil.Discard, ioutil.Discard); err == nil {
+			t.Errorf("run(%q): got no error", strings.Join(args, " "))
+		}
+	}
+}
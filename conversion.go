@@ -169,7 +169,7 @@ func (z *Int) SetBytes16(in []byte) *Int {
 	return z
 }
 
-// SetBytes16 is identical to SetBytes(in[:24]), but panics is input is too short
+// SetBytes24 is identical to SetBytes(in[:24]), but panics is input is too short
 func (z *Int) SetBytes24(in []byte) *Int {
 	_ = in[23] // bounds check hint to compiler; see golang.org/issue/14808
 	z[3] = 0
@@ -179,6 +179,7 @@ func (z *Int) SetBytes24(in []byte) *Int {
 	return z
 }
 
+// SetBytes32 is identical to SetBytes(in[:32]), but panics is input is too short
 func (z *Int) SetBytes32(in []byte) *Int {
 	_ = in[31] // bounds check hint to compiler; see golang.org/issue/14808
 	z[3] = binary.BigEndian.Uint64(in[0:8])
@@ -301,6 +302,7 @@ func (z *Int) SetBytes12(in []byte) *Int {
 	return z
 }
 
+// SetBytes20 is identical to SetBytes(in[:20]), but panics is input is too short
 func (z *Int) SetBytes20(in []byte) *Int {
 	_ = in[19] // bounds check hint to compiler; see golang.org/issue/14808
 	z[3] = 0
@@ -344,7 +346,7 @@ func (z *Int) SetBytes21(in []byte) *Int {
 }
 
 func (z *Int) SetBytes29(in []byte) *Int {
-	_ = in[23] // bounds check hint to compiler; see golang.org/issue/14808
+	_ = in[28] // bounds check hint to compiler; see golang.org/issue/14808
 	z[3] = bigEndianUint40(in[0:5])
 	z[2] = binary.BigEndian.Uint64(in[5:13])
 	z[1] = binary.BigEndian.Uint64(in[13:21])
@@ -418,6 +420,45 @@ func (z *Int) SetBytes31(in []byte) *Int {
 	return z
 }
 
+// SetBytesLE interprets buf as the bytes of a little-endian unsigned
+// integer, sets z to that value, and returns z.
+// If buf is larger than 32 bytes, the first 32 bytes is used.
+func (z *Int) SetBytesLE(buf []byte) *Int {
+	if len(buf) >= 32 {
+		return z.SetBytes32LE(buf)
+	}
+	var b [32]byte
+	copy(b[:], buf)
+	return z.SetBytes32LE(b[:])
+}
+
+// SetBytes32LE is identical to SetBytesLE(in[:32]), but panics is input is too short
+func (z *Int) SetBytes32LE(in []byte) *Int {
+	_ = in[31] // bounds check hint to compiler; see golang.org/issue/14808
+	z[0] = binary.LittleEndian.Uint64(in[0:8])
+	z[1] = binary.LittleEndian.Uint64(in[8:16])
+	z[2] = binary.LittleEndian.Uint64(in[16:24])
+	z[3] = binary.LittleEndian.Uint64(in[24:32])
+	return z
+}
+
+// Bytes32LE returns the value of z as a 32-byte little-endian array.
+func (z *Int) Bytes32LE() [32]byte {
+	var b [32]byte
+	binary.LittleEndian.PutUint64(b[0:8], z[0])
+	binary.LittleEndian.PutUint64(b[8:16], z[1])
+	binary.LittleEndian.PutUint64(b[16:24], z[2])
+	binary.LittleEndian.PutUint64(b[24:32], z[3])
+	return b
+}
+
+// BytesLE returns the value of z as a little-endian byte slice, without
+// trailing zero bytes.
+func (z *Int) BytesLE() []byte {
+	b := z.Bytes32LE()
+	return b[:z.ByteLen()]
+}
+
 // Utility methods that are "missing" among the bigEndian.UintXX methods.
 
 func bigEndianUint40(b []byte) uint64 {
@@ -491,10 +532,13 @@ const (
 
 // Hex encodes z in 0x-prefixed hexadecimal form.
 func (z *Int) Hex() string {
-	// This implementation is not optimal, it allocates a full
-	// 66-byte output buffer which it fills. It could instead allocate a smaller
-	// buffer, and omit the final crop-stage.
-	output := make([]byte, 66)
+	var output [66]byte
+	return string(z.hex(&output))
+}
+
+// hex writes the 0x-prefixed hexadecimal form of z to the end of output,
+// and returns the written part of it.
+func (z *Int) hex(output *[66]byte) []byte {
 	nibbles := (z.BitLen() + 3) / 4 // nibbles [0,64]
 	if nibbles == 0 {
 		nibbles = 1
@@ -522,7 +566,7 @@ func (z *Int) Hex() string {
 	}
 	output[64-nibbles] = '0'
 	output[65-nibbles] = 'x'
-	return string(output[64-nibbles:])
+	return output[64-nibbles:]
 }
 
 var (
@@ -197,6 +197,39 @@ func TestSetBytes(t *testing.T) {
 	}
 }
 
+// reverseBytes returns a reversed copy of b.
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func TestSetBytesLE(t *testing.T) {
+	buf := hex2Bytes("aaaa12131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f3031bbbb")
+	for i := 0; i < 35; i++ {
+		le := reverseBytes(buf[0:i])
+		exp := new(Int).SetAllOne().SetBytes(buf[0:i])
+		z := new(Int).SetAllOne().SetBytesLE(le)
+		if !z.Eq(exp) {
+			t.Errorf("testcase %d: exp %x, got %x", i, exp, z)
+		}
+		if i <= 32 {
+			if got := z.BytesLE(); !bytes.Equal(got, reverseBytes(exp.Bytes())) {
+				t.Errorf("testcase %d: BytesLE: got %x", i, got)
+			}
+			b32 := z.Bytes32LE()
+			if back := new(Int).SetBytes32LE(b32[:]); !back.Eq(z) {
+				t.Errorf("testcase %d: round trip: got %x, want %x", i, back, z)
+			}
+		}
+	}
+	if z := new(Int).SetAllOne().SetBytesLE(nil); !z.IsZero() {
+		t.Errorf("nil-test: got %x", z)
+	}
+}
+
 func BenchmarkSetBytes(b *testing.B) {
 
 	val := new(Int)
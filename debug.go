@@ -0,0 +1,36 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DebugString renders z in several forms at once, for diagnosing
+// reduction and shifting bugs:
+//
+//	dec     255
+//	hex     0xff
+//	bitlen  8
+//	popcnt  8
+//	limb 3  0x0000000000000000
+//	limb 2  0x0000000000000000
+//	limb 1  0x0000000000000000
+//	limb 0  0x00000000000000ff
+//
+// Limbs are listed from the most significant (z[3]) to the least
+// significant (z[0]).
+func (z *Int) DebugString() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "dec     %s\n", z.ToBig().String())
+	fmt.Fprintf(&sb, "hex     %s\n", z.Hex())
+	fmt.Fprintf(&sb, "bitlen  %d\n", z.BitLen())
+	fmt.Fprintf(&sb, "popcnt  %d\n", z.OnesCount())
+	for i := len(z) - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, "limb %d  %#016x\n", i, z[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,37 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "testing"
+
+func TestDebugString(t *testing.T) {
+	for _, tc := range []struct {
+		x    *Int
+		want string
+	}{
+		{new(Int), `dec     0
+hex     0x0
+bitlen  0
+popcnt  0
+limb 3  0x0000000000000000
+limb 2  0x0000000000000000
+limb 1  0x0000000000000000
+limb 0  0x0000000000000000
+`},
+		{&Int{0xff, 0, 1, 0x8000000000000000}, `dec     57896044618658097711785492504343953926975274699741220483192166611388333031679
+hex     0x80000000000000000000000000000001000000000000000000000000000000ff
+bitlen  256
+popcnt  10
+limb 3  0x8000000000000000
+limb 2  0x0000000000000001
+limb 1  0x0000000000000000
+limb 0  0x00000000000000ff
+`},
+	} {
+		if got := tc.x.DebugString(); got != tc.want {
+			t.Errorf("got\n%s\nwant\n%s", got, tc.want)
+		}
+	}
+}
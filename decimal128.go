@@ -0,0 +1,121 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "errors"
+
+var (
+	ErrDecimal128NaN      = errors.New("decimal128: value is NaN")
+	ErrDecimal128Infinity = errors.New("decimal128: value is infinite")
+	ErrDecimal128Negative = errors.New("decimal128: negative value")
+	ErrDecimal128Range    = errors.New("decimal128: value > 256 bits")
+)
+
+const (
+	decimal128Digits = 34   // precision in decimal digits
+	decimal128Bias   = 6176 // exponent bias
+)
+
+// Decimal128 is an IEEE 754-2008 decimal128 value in the binary integer
+// decimal (BID) encoding, as used by BSON and several databases. Hi holds
+// the sign, combination field and top 49 bits of the coefficient, Lo the
+// low 64 bits of the coefficient.
+type Decimal128 struct {
+	Hi, Lo uint64
+}
+
+// ToDecimal128 returns z encoded as a decimal128. Values with more than 34
+// significant digits are rounded to nearest, ties to even, and exact is
+// false if rounding changed the value.
+func (z *Int) ToDecimal128() (d Decimal128, exact bool) {
+	// Find the number of digits k to drop: the least k with z < 10**(34+k).
+	k := uint64(0)
+	for ; decimal128Digits+k <= maxPow10; k++ {
+		var p Int
+		p.Exp(&Int{10}, &Int{decimal128Digits + k})
+		if z.Lt(&p) {
+			break
+		}
+	}
+	coeff := *z
+	exact = true
+	if k > 0 {
+		var p, rem, half Int
+		p.Exp(&Int{10}, &Int{k})
+		rem.Mod(z, &p)
+		coeff.Div(z, &p)
+		exact = rem.IsZero()
+		half.Rsh(&p, 1)
+		if c := rem.Cmp(&half); c > 0 || (c == 0 && coeff[0]&1 == 1) {
+			coeff.AddUint64(&coeff, 1)
+			var max Int
+			max.Exp(&Int{10}, &Int{decimal128Digits})
+			if coeff.Eq(&max) {
+				coeff.Div(&coeff, &Int{10})
+				k++
+			}
+		}
+	}
+	d.Hi = (k+decimal128Bias)<<49 | coeff[1]
+	d.Lo = coeff[0]
+	return d, exact
+}
+
+// SetDecimal128 sets z to the integer part of the decimal128 value d.
+// It returns exact == false if d had a non-zero fractional part, which is
+// truncated. It returns an error if d is NaN, infinite, negative or larger
+// than 256 bits; z is left unmodified in that case.
+// Non-canonical encodings are treated as zero, as required by the standard.
+func (z *Int) SetDecimal128(d Decimal128) (exact bool, err error) {
+	switch d.Hi >> 58 & 0x1f {
+	case 0x1f:
+		return false, ErrDecimal128NaN
+	case 0x1e:
+		return false, ErrDecimal128Infinity
+	}
+	var coeff Int
+	if d.Hi>>61&3 != 3 {
+		coeff = Int{d.Lo, d.Hi & (1<<49 - 1)}
+		var max Int
+		max.Exp(&Int{10}, &Int{decimal128Digits})
+		if !coeff.Lt(&max) {
+			coeff.Clear()
+		}
+	}
+	if coeff.IsZero() {
+		z.Clear()
+		return true, nil
+	}
+	if d.Hi>>63 != 0 {
+		return false, ErrDecimal128Negative
+	}
+	exp := int(d.Hi>>49&0x3fff) - decimal128Bias
+	switch {
+	case exp == 0:
+		z.Set(&coeff)
+		return true, nil
+	case exp > 0:
+		if exp > maxPow10 {
+			return false, ErrDecimal128Range
+		}
+		var p Int
+		p.Exp(&Int{10}, &Int{uint64(exp)})
+		if _, overflow := coeff.MulOverflow(&coeff, &p); overflow {
+			return false, ErrDecimal128Range
+		}
+		z.Set(&coeff)
+		return true, nil
+	}
+	if -exp >= decimal128Digits {
+		// The coefficient is below 10**34, so the integer part is zero.
+		z.Clear()
+		return false, nil
+	}
+	var p, rem Int
+	p.Exp(&Int{10}, &Int{uint64(-exp)})
+	rem.Mod(&coeff, &p)
+	z.Div(&coeff, &p)
+	return rem.IsZero(), nil
+}
@@ -0,0 +1,109 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecimal128Vectors(t *testing.T) {
+	for i, tc := range []struct {
+		d     Decimal128
+		want  string
+		exact bool
+		err   error
+	}{
+		{Decimal128{0x3040000000000000, 0}, "0", true, nil},
+		{Decimal128{0xb040000000000000, 0}, "0", true, nil}, // -0
+		{Decimal128{0x3040000000000000, 1}, "1", true, nil},
+		{Decimal128{0x3042000000000000, 12}, "120", true, nil},                // 12E+1
+		{Decimal128{0x303e000000000000, 15}, "1", false, nil},                 // 1.5
+		{Decimal128{0x303e000000000000, 10}, "1", true, nil},                  // 1.0
+		{Decimal128{0x2ffe314dc6448d93, 0x38c15b09ffffffff}, "0", false, nil}, // 0.999...
+		{Decimal128{0x3041ed09bead87c0, 0x378d8e63ffffffff}, "9999999999999999999999999999999999", true, nil},
+		{Decimal128{0x3041ed09bead87c0, 0x378d8e6400000000}, "0", true, nil}, // non-canonical
+		{Decimal128{0x6c10000000000000, 0}, "0", true, nil},                  // non-canonical, 11 form
+		{Decimal128{0x5fffed09bead87c0, 0x378d8e63ffffffff}, "", false, ErrDecimal128Range},
+		{Decimal128{0x30dc000000000000, 1}, "", false, ErrDecimal128Range}, // 1E+78
+		{Decimal128{0xb040000000000000, 1}, "", false, ErrDecimal128Negative},
+		{Decimal128{0x7800000000000000, 0}, "", false, ErrDecimal128Infinity},
+		{Decimal128{0x7c00000000000000, 0}, "", false, ErrDecimal128NaN},
+	} {
+		z := Int{1, 2, 3, 4}
+		exact, err := z.SetDecimal128(tc.d)
+		if err != tc.err {
+			t.Errorf("test %d: got error %v, want %v", i, err, tc.err)
+			continue
+		}
+		if err != nil {
+			if z != (Int{1, 2, 3, 4}) {
+				t.Errorf("test %d: receiver modified on error", i)
+			}
+			continue
+		}
+		if exact != tc.exact {
+			t.Errorf("test %d: got exact %v, want %v", i, exact, tc.exact)
+		}
+		if got := z.ToBig().String(); got != tc.want {
+			t.Errorf("test %d: got %s, want %s", i, got, tc.want)
+		}
+	}
+}
+
+func TestDecimal128RoundTrip(t *testing.T) {
+	ten := big.NewInt(10)
+	// bigRound rounds b to 34 significant digits, ties to even.
+	bigRound := func(b *big.Int) *big.Int {
+		digits := len(b.String())
+		if b.Sign() == 0 || digits <= 34 {
+			return new(big.Int).Set(b)
+		}
+		p := new(big.Int).Exp(ten, big.NewInt(int64(digits-34)), nil)
+		q, r := new(big.Int).QuoRem(b, p, new(big.Int))
+		if c := new(big.Int).Lsh(r, 1).Cmp(p); c > 0 || (c == 0 && q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(1))
+		}
+		return q.Mul(q, p)
+	}
+	check := func(b *big.Int) {
+		f, _ := FromBig(b)
+		d, exact := f.ToDecimal128()
+		want := bigRound(b)
+		if exact != (want.Cmp(b) == 0) {
+			t.Errorf("%v: got exact %v", b, exact)
+		}
+		var back Int
+		if want.BitLen() > 256 {
+			if _, err := back.SetDecimal128(d); err != ErrDecimal128Range {
+				t.Errorf("%v: got error %v, want ErrDecimal128Range", b, err)
+			}
+			return
+		}
+		if ok, err := back.SetDecimal128(d); err != nil || !ok {
+			t.Errorf("%v: decode failed: %v %v", b, ok, err)
+			return
+		}
+		requireEq(t, want, &back, "Decimal128")
+	}
+	for _, s := range []string{
+		"0", "1", "9999999999999999999999999999999999",
+		"10000000000000000000000000000000000",
+		"99999999999999999999999999999999995", // rounds up to 10**35
+		"12345678901234567890123456789012345", // tie, rounds to even
+		"12345678901234567890123456789012355",
+	} {
+		b, _ := new(big.Int).SetString(s, 10)
+		check(b)
+	}
+	check(new(big.Int).Sub(bigtt256, big.NewInt(1)))
+	for i := 0; i < 1000; i++ {
+		b, _, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(b)
+	}
+}
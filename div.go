@@ -6,10 +6,39 @@ package uint256
 
 import "math/bits"
 
-// reciprocal2by1 computes <^d, ^0> / d.
+// reciprocalTable holds the 11-bit initial reciprocal estimates
+// 0x7fd00 / (0x100 | d9) indexed by the 8 bits following the top bit of d.
+var reciprocalTable = func() (t [256]uint16) {
+	for d9 := range t {
+		t[d9] = uint16(0x7fd00 / (0x100 | d9))
+	}
+	return t
+}()
+
+// reciprocal2by1 computes <^d, ^0> / d for a normalized d (top bit set).
+// Instead of a hardware division, it refines a table estimate with Newton
+// iterations.
+// Implementation ported from https://github.com/chfast/intx and is based on
+// "Improved division by invariant integers", Algorithm 2.
 func reciprocal2by1(d uint64) uint64 {
-	reciprocal, _ := bits.Div64(^d, ^uint64(0), d)
-	return reciprocal
+	d9 := d >> 55
+	v0 := uint64(reciprocalTable[d9-256])
+
+	d40 := (d >> 24) + 1
+	v1 := (v0 << 11) - uint64(uint32(v0*v0*d40>>40)) - 1
+
+	v2 := (v1 << 13) + (v1 * (0x1000000000000000 - v1*d40) >> 47)
+
+	d0 := d & 1
+	d63 := (d >> 1) + d0 // ceil(d/2)
+	e := ((v2 >> 1) & (0 - d0)) - v2*d63
+	hi, _ := bits.Mul64(v2, e)
+	v3 := (hi >> 1) + (v2 << 31)
+
+	hi, lo := bits.Mul64(v3, d)
+	_, carry := bits.Add64(lo, d, 0)
+	hi += carry
+	return v3 - hi - d
 }
 
 // udivrem2by1 divides <uh, ul> / d and produces both quotient and remainder.
@@ -36,3 +65,73 @@ func udivrem2by1(uh, ul, d, reciprocal uint64) (quot, rem uint64) {
 
 	return qh, r
 }
+
+// DivMod returns the quotient x/y and the remainder x%y, computed with a
+// single division.
+// If y == 0, the quotient and remainder are 0 (OBS: differs from the big.Int)
+func DivMod(x, y *Int) (q, r Int) {
+	switch {
+	case y.IsZero():
+		return q, r
+	case x.Lt(y):
+		return q, *x
+	case x.IsUint64():
+		// Here y is a nonzero uint64 as well.
+		return Int{x[0] / y[0]}, Int{x[0] % y[0]}
+	}
+	r = udivrem(q[:], x[:], y)
+	return q, r
+}
+
+// DivModUint64 sets z to the quotient x/y and returns z and the remainder
+// x%y. It divides word by word, avoiding the general division.
+// If y == 0, z and the remainder are set to 0 (OBS: differs from the big.Int)
+func (z *Int) DivModUint64(x *Int, y uint64) (*Int, uint64) {
+	if y == 0 {
+		return z.Clear(), 0
+	}
+	var r uint64
+	z[3], r = bits.Div64(0, x[3], y)
+	z[2], r = bits.Div64(r, x[2], y)
+	z[1], r = bits.Div64(r, x[1], y)
+	z[0], r = bits.Div64(r, x[0], y)
+	return z, r
+}
+
+// DivUint64 sets z to the quotient x/y and returns z.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) DivUint64(x *Int, y uint64) *Int {
+	z.DivModUint64(x, y)
+	return z
+}
+
+// ModUint64 returns z%y.
+// If y == 0, the result is 0 (OBS: differs from the big.Int)
+func (z *Int) ModUint64(y uint64) uint64 {
+	if y == 0 {
+		return 0
+	}
+	_, r := bits.Div64(0, z[3], y)
+	_, r = bits.Div64(r, z[2], y)
+	_, r = bits.Div64(r, z[1], y)
+	_, r = bits.Div64(r, z[0], y)
+	return r
+}
+
+// DivMod512 divides the 512-bit number hi*2**256 + lo by d, and returns the
+// quotient qHi*2**256 + qLo and the remainder r.
+// If d == 0, the quotient and remainder are 0 (OBS: differs from the big.Int)
+func DivMod512(hi, lo, d *Int) (qHi, qLo, r Int) {
+	if d.IsZero() {
+		return qHi, qLo, r
+	}
+	if hi.IsZero() && lo.Lt(d) {
+		return qHi, qLo, *lo
+	}
+	u := [8]uint64{lo[0], lo[1], lo[2], lo[3], hi[0], hi[1], hi[2], hi[3]}
+	var quot [8]uint64
+	r = udivrem(quot[:], u[:], d)
+	copy(qLo[:], quot[:4])
+	copy(qHi[:], quot[4:])
+	return qHi, qLo, r
+}
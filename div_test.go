@@ -0,0 +1,186 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestReciprocal2by1(t *testing.T) {
+	check := func(d uint64) {
+		want, _ := bits.Div64(^d, ^uint64(0), d)
+		if got := reciprocal2by1(d); got != want {
+			t.Fatalf("reciprocal2by1(%#x) = %#x, want %#x", d, got, want)
+		}
+	}
+	for i := uint64(0); i < 256; i++ {
+		d := 1<<63 | i<<55
+		check(d)
+		check(d | 1)
+		check(d | (1<<55 - 1))
+	}
+	check(^uint64(0))
+	for i := 0; i < 100000; i++ {
+		check(rand.Uint64() | 1<<63)
+	}
+}
+
+func BenchmarkReciprocal2by1(b *testing.B) {
+	var sink uint64
+	for i := 0; i < b.N; i++ {
+		sink += reciprocal2by1(uint64(i) | 1<<63)
+	}
+	_ = sink
+}
+
+func TestDivMod(t *testing.T) {
+	check := func(x, y *Int) {
+		t.Helper()
+		wantQ, wantR := new(big.Int), new(big.Int)
+		if !y.IsZero() {
+			wantQ.QuoRem(x.ToBig(), y.ToBig(), wantR)
+		}
+		q, r := DivMod(x, y)
+		if q.ToBig().Cmp(wantQ) != 0 || r.ToBig().Cmp(wantR) != 0 {
+			t.Fatalf("DivMod(%x, %x): got (%x, %x), want (%x, %x)", x, y, &q, &r, wantQ, wantR)
+		}
+	}
+	ints := []*Int{new(Int), NewInt(1), NewInt(3), NewInt(10), new(Int).SetAllOne(), {0, 0, 0, 1}, {0, 1}, {5, 0, 1}}
+	for _, x := range ints {
+		for _, y := range ints {
+			check(x, y)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		var x, y Int
+		for j := range x {
+			x[j], y[j] = rand.Uint64(), rand.Uint64()
+		}
+		for j := 0; j < i%4; j++ {
+			y[3-j] = 0
+		}
+		for j := 0; j < i/4%4; j++ {
+			x[3-j] = 0
+		}
+		check(&x, &y)
+	}
+}
+
+func BenchmarkDivMod(b *testing.B) {
+	x := &Int{0x0123456789abcdef, 0xfedcba9876543210, 0x0123456789abcdef, 0xfedcba9876543210}
+	y := &Int{0x9876543210fedcba, 0x0123456789abcdef}
+	b.Run("DivMod", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DivMod(x, y)
+		}
+	})
+	b.Run("Div+Mod", func(b *testing.B) {
+		var q, r Int
+		for i := 0; i < b.N; i++ {
+			q.Div(x, y)
+			r.Mod(x, y)
+		}
+	})
+}
+
+func TestDivModUint64(t *testing.T) {
+	check := func(x *Int, y uint64) {
+		t.Helper()
+		wantQ, wantR := new(big.Int), new(big.Int)
+		if y != 0 {
+			wantQ.QuoRem(x.ToBig(), new(big.Int).SetUint64(y), wantR)
+		}
+		q, r := new(Int).DivModUint64(x, y)
+		if q.ToBig().Cmp(wantQ) != 0 || r != wantR.Uint64() {
+			t.Fatalf("DivModUint64(%x, %d): got (%x, %d), want (%x, %x)", x, y, q, r, wantQ, wantR)
+		}
+		requireEq(t, wantQ, new(Int).DivUint64(x, y), "DivUint64")
+		if got := x.ModUint64(y); got != wantR.Uint64() {
+			t.Fatalf("ModUint64(%x, %d): got %d, want %x", x, y, got, wantR)
+		}
+	}
+	ys := []uint64{0, 1, 2, 3, 10, 1e9, 1e18, 1 << 63, ^uint64(0)}
+	xs := []*Int{new(Int), NewInt(1), NewInt(9), NewInt(1e18), new(Int).SetAllOne(), {0, 0, 0, 1}, {0, 1}}
+	for i := 0; i < 200; i++ {
+		xs = append(xs, &Int{rand.Uint64(), rand.Uint64(), rand.Uint64(), rand.Uint64() >> uint(i%64)})
+		ys = append(ys, rand.Uint64()>>uint(i%64))
+	}
+	for _, x := range xs {
+		for _, y := range ys {
+			check(x, y)
+		}
+	}
+}
+
+func BenchmarkDivUint64(b *testing.B) {
+	x := &Int{0x0123456789abcdef, 0xfedcba9876543210, 0x0123456789abcdef, 0xfedcba9876543210}
+	for _, y := range []uint64{10, 1e9, 1e18, 1e18 + 1} {
+		b.Run(fmt.Sprintf("DivUint64/%d", y), func(b *testing.B) {
+			var z Int
+			for i := 0; i < b.N; i++ {
+				z.DivUint64(x, y)
+			}
+		})
+	}
+	// Peeling off the decimal digits of x, 18 at a time.
+	b.Run("digits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for z := *x; !z.IsZero(); {
+				z.DivModUint64(&z, 1e18)
+			}
+		}
+	})
+	b.Run("Div", func(b *testing.B) {
+		var z Int
+		y := NewInt(1e18)
+		for i := 0; i < b.N; i++ {
+			z.Div(x, y)
+		}
+	})
+}
+
+func TestDivMod512(t *testing.T) {
+	check := func(hi, lo, d *Int) {
+		t.Helper()
+		x := new(big.Int).Lsh(hi.ToBig(), 256)
+		x.Add(x, lo.ToBig())
+		wantQ, wantR := new(big.Int), new(big.Int)
+		if !d.IsZero() {
+			wantQ.QuoRem(x, d.ToBig(), wantR)
+		}
+		qHi, qLo, r := DivMod512(hi, lo, d)
+		gotQ := new(big.Int).Lsh(qHi.ToBig(), 256)
+		gotQ.Add(gotQ, qLo.ToBig())
+		if gotQ.Cmp(wantQ) != 0 || r.ToBig().Cmp(wantR) != 0 {
+			t.Fatalf("DivMod512(%x, %x, %x): got (%x, %x), want (%x, %x)", hi, lo, d, gotQ, &r, wantQ, wantR)
+		}
+	}
+	ints := []*Int{new(Int), NewInt(1), NewInt(3), new(Int).SetAllOne(), {0, 0, 0, 1}, {0, 1}}
+	for _, hi := range ints {
+		for _, lo := range ints {
+			for _, d := range ints {
+				check(hi, lo, d)
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		var hi, lo, d Int
+		for j := range hi {
+			hi[j], lo[j], d[j] = rand.Uint64(), rand.Uint64(), rand.Uint64()
+		}
+		// Vary the sizes of the dividend and divisor.
+		for j := 0; j < i%4; j++ {
+			d[3-j] = 0
+		}
+		for j := 0; j < i/4%5; j++ {
+			hi[3-j%4] = 0
+		}
+		check(&hi, &lo, &d)
+	}
+}
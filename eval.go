@@ -0,0 +1,326 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "fmt"
+
+// EvalError is returned by Eval for malformed expressions and undefined
+// variables.
+type EvalError struct {
+	Offset int // byte offset in the expression
+	Msg    string
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("eval: %s at offset %d", e.Msg, e.Offset)
+}
+
+// evalNode is a node in the syntax tree of an expression. Leaves have op
+// "num" or "var", unary minus is "neg", all other ops are binary.
+type evalNode struct {
+	op   string
+	pos  int
+	val  Int
+	name string
+	x, y *evalNode
+}
+
+// Eval evaluates the expression expr over 256-bit unsigned integers.
+//
+// Operands are integer literals in Go syntax, as accepted by
+// SetFromLiteral, or names looked up in vars. The operators are, from
+// lowest to highest precedence:
+//
+//	mod        x mod m: evaluate x modulo m
+//	<< >>      shifts
+//	+ -        addition, subtraction
+//	* / %      multiplication, division, remainder
+//	-          unary minus
+//	**         exponentiation (right-associative)
+//
+// Parentheses group as usual. Outside of a mod context arithmetic wraps
+// modulo 2**256, like the methods of Int. Inside "x mod m", every
+// operation in x is performed modulo m without intermediate wrapping, so
+// for example "a * b mod m" equals MulMod(a, b, m), and "a / b mod m"
+// multiplies a by the inverse of b modulo m. Remainder has no meaning
+// there and is rejected. Moduli, exponents and shift amounts are not
+// reduced.
+//
+// Division, remainder or mod by zero return ErrDivByZero. Syntax errors,
+// undefined variables, divisors without an inverse modulo m and remainders
+// inside mod return an *EvalError.
+func Eval(expr string, vars map[string]*Int) (*Int, error) {
+	p := &evalParser{src: expr}
+	p.next()
+	n, err := p.parseMod()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, p.errorf("unexpected %q", p.tok)
+	}
+	var z Int
+	if err := z.evalNode(n, vars, nil); err != nil {
+		return nil, err
+	}
+	return &z, nil
+}
+
+// evalNode sets z to the value of n, reduced modulo m if m is not nil.
+func (z *Int) evalNode(n *evalNode, vars map[string]*Int, m *Int) error {
+	switch n.op {
+	case "num":
+		z.Set(&n.val)
+	case "var":
+		v := vars[n.name]
+		if v == nil {
+			return &EvalError{n.pos, fmt.Sprintf("undefined variable %q", n.name)}
+		}
+		z.Set(v)
+	case "mod":
+		// The modulus itself is not reduced by an enclosing context.
+		var mod Int
+		if err := mod.evalNode(n.y, vars, nil); err != nil {
+			return err
+		}
+		if mod.IsZero() {
+			return ErrDivByZero
+		}
+		if err := z.evalNode(n.x, vars, &mod); err != nil {
+			return err
+		}
+	case "neg":
+		if err := z.evalNode(n.x, vars, m); err != nil {
+			return err
+		}
+		if m != nil {
+			z.subModReduced(new(Int), z, m)
+		} else {
+			z.Neg(z)
+		}
+	case "**", "<<", ">>":
+		// The right operand is an exponent or shift amount, never reduced.
+		var x, y Int
+		if err := x.evalNode(n.x, vars, m); err != nil {
+			return err
+		}
+		if err := y.evalNode(n.y, vars, nil); err != nil {
+			return err
+		}
+		switch {
+		case n.op == "**" && m != nil:
+			z.ExpMod(&x, &y, m)
+		case n.op == "**":
+			z.Exp(&x, &y)
+		case n.op == "<<" && m != nil:
+			var p Int
+			z.MulMod(&x, p.ExpMod(&Int{2}, &y, m), m)
+		case n.op == "<<":
+			z.Lsh(&x, shiftAmount(&y))
+		default:
+			z.Rsh(&x, shiftAmount(&y))
+		}
+	default:
+		if n.op == "%" && m != nil {
+			return &EvalError{n.pos, "remainder inside mod"}
+		}
+		var x, y Int
+		if err := x.evalNode(n.x, vars, m); err != nil {
+			return err
+		}
+		if err := y.evalNode(n.y, vars, m); err != nil {
+			return err
+		}
+		switch n.op {
+		case "+":
+			if m != nil {
+				z.AddMod(&x, &y, m)
+			} else {
+				z.Add(&x, &y)
+			}
+		case "-":
+			if m != nil {
+				z.subModReduced(&x, &y, m)
+			} else {
+				z.Sub(&x, &y)
+			}
+		case "*":
+			if m != nil {
+				z.MulMod(&x, &y, m)
+			} else {
+				z.Mul(&x, &y)
+			}
+		case "/":
+			if m != nil {
+				var inv Int
+				if _, ok := inv.ModInverse(&y, m); !ok {
+					return &EvalError{n.pos, "divisor not invertible"}
+				}
+				z.MulMod(&x, &inv, m)
+			} else if _, err := z.CheckedDiv(&x, &y); err != nil {
+				return err
+			}
+		case "%":
+			if _, err := z.CheckedMod(&x, &y); err != nil {
+				return err
+			}
+		}
+	}
+	if m != nil {
+		z.Mod(z, m)
+	}
+	return nil
+}
+
+// shiftAmount returns x as a shift amount, saturated at 256.
+func shiftAmount(x *Int) uint {
+	if !x.IsUint64() || x.Uint64() > 256 {
+		return 256
+	}
+	return uint(x.Uint64())
+}
+
+// evalParser is a recursive descent parser for Eval expressions.
+type evalParser struct {
+	src    string
+	off    int    // offset of the next unread byte
+	tok    string // current token, "" at the end of input
+	tokPos int    // offset of the current token
+}
+
+func (p *evalParser) errorf(format string, args ...interface{}) error {
+	return &EvalError{p.tokPos, fmt.Sprintf(format, args...)}
+}
+
+// next advances to the next token.
+func (p *evalParser) next() {
+	for p.off < len(p.src) && (p.src[p.off] == ' ' || p.src[p.off] == '\t' || p.src[p.off] == '\n') {
+		p.off++
+	}
+	p.tokPos = p.off
+	if p.off == len(p.src) {
+		p.tok = ""
+		return
+	}
+	start := p.off
+	switch c := p.src[p.off]; {
+	case isEvalIdent(c) || c >= '0' && c <= '9':
+		for p.off < len(p.src) && (isEvalIdent(p.src[p.off]) || p.src[p.off] >= '0' && p.src[p.off] <= '9') {
+			p.off++
+		}
+	case c == '*' || c == '<' || c == '>':
+		p.off++
+		if p.off < len(p.src) && p.src[p.off] == c {
+			p.off++
+		}
+	default:
+		p.off++
+	}
+	p.tok = p.src[start:p.off]
+}
+
+func isEvalIdent(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// binary parses a left-associative chain of the given operators.
+func (p *evalParser) binary(operand func() (*evalNode, error), ops ...string) (*evalNode, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, pos := p.tok, p.tokPos
+		found := false
+		for _, o := range ops {
+			found = found || op == o
+		}
+		if !found {
+			return x, nil
+		}
+		p.next()
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &evalNode{op: op, pos: pos, x: x, y: y}
+	}
+}
+
+func (p *evalParser) parseMod() (*evalNode, error) {
+	return p.binary(p.parseShift, "mod")
+}
+
+func (p *evalParser) parseShift() (*evalNode, error) {
+	return p.binary(p.parseSum, "<<", ">>")
+}
+
+func (p *evalParser) parseSum() (*evalNode, error) {
+	return p.binary(p.parseTerm, "+", "-")
+}
+
+func (p *evalParser) parseTerm() (*evalNode, error) {
+	return p.binary(p.parseUnary, "*", "/", "%")
+}
+
+func (p *evalParser) parseUnary() (*evalNode, error) {
+	if p.tok == "-" {
+		pos := p.tokPos
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &evalNode{op: "neg", pos: pos, x: x}, nil
+	}
+	return p.parsePower()
+}
+
+func (p *evalParser) parsePower() (*evalNode, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "**" {
+		return x, nil
+	}
+	pos := p.tokPos
+	p.next()
+	y, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &evalNode{op: "**", pos: pos, x: x, y: y}, nil
+}
+
+func (p *evalParser) parsePrimary() (*evalNode, error) {
+	tok, pos := p.tok, p.tokPos
+	switch {
+	case tok == "":
+		return nil, p.errorf("unexpected end of expression")
+	case tok == "(":
+		p.next()
+		x, err := p.parseMod()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" {
+			return nil, p.errorf("expected ')'")
+		}
+		p.next()
+		return x, nil
+	case tok[0] >= '0' && tok[0] <= '9':
+		n := &evalNode{op: "num", pos: pos}
+		if err := n.val.SetFromLiteral(tok); err != nil {
+			return nil, &EvalError{pos, fmt.Sprintf("%v %q", err, tok)}
+		}
+		p.next()
+		return n, nil
+	case isEvalIdent(tok[0]) && tok != "mod":
+		p.next()
+		return &evalNode{op: "var", pos: pos, name: tok}, nil
+	}
+	return nil, p.errorf("unexpected %q", tok)
+}
@@ -0,0 +1,121 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]*Int{
+		"x":   NewInt(10),
+		"y_2": NewInt(3),
+		"max": new(Int).SetAllOne(),
+	}
+	for _, tc := range []struct {
+		expr, want string
+	}{
+		{"0", "0x0"},
+		{"1 + 2 * 3", "0x7"},
+		{"(1 + 2) * 3", "0x9"},
+		{"x / y_2 + x % y_2", "0x4"},
+		{"2 ** 3 ** 2", "0x200"},
+		{"-2 ** 2", "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffc"},
+		{"1 << 4 + 1", "0x20"},
+		{"0x100 >> 4", "0x10"},
+		{"0b1_0 + 0o7 + 1_000", "0x3f1"},
+		{"1 << 256", "0x0"},
+		{"1 << 0x10000000000000000000", "0x0"},
+		{"max + 1", "0x0"},
+		{"0 - 1", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"max * max mod 7", "0x1"},     // (2**256-1)**2 mod 7, not wrapped
+		{"max * max % 7", "0x1"},       // wraps to 1 first
+		{"max + max mod 10", "0x0"},    // 2**257-2 mod 10
+		{"1 << 256 mod 1000", "0x3a8"}, // 2**256 mod 1000 = 936
+		{"-1 mod 7", "0x6"},
+		{"3 - 5 mod 7", "0x5"},
+		{"x ** 100 mod 13", "0x3"},
+		{"x mod 7 mod 5", "0x3"},
+		{"((x mod 7) + 4) mod 5", "0x2"},
+		{"(x + 1 mod 7) * x", "0x28"},
+		{"6 / 4 mod 5", "0x4"}, // 6 * 4**-1 = 6 * 4 mod 5
+		{"1 / x mod 7", "0x5"},
+		{"(x / 3) mod 7", "0x1"}, // 10 = 3 mod 7
+		{"(x / 3) % 7", "0x3"},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639935", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	} {
+		got, err := Eval(tc.expr, vars)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tc.expr, err)
+			continue
+		}
+		if got.Hex() != tc.want {
+			t.Errorf("%q: got %s, want %s", tc.expr, got.Hex(), tc.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		expr   string
+		offset int // -1 for ErrDivByZero
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"(1 + 2", 6},
+		{"1 2", 2},
+		{"z + 1", 0},
+		{"1 + mod", 4},
+		{"0x", 0},
+		{"12a", 0},
+		{"0xg", 0},
+		{"1 $ 2", 2},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639936", 0},
+		{"1 / 0", -1},
+		{"1 % (2 - 2)", -1},
+		{"1 mod 0", -1},
+		{"1 / 2 mod 4", 2},
+		{"1 / 7 mod 7", 2},
+		{"5 % 3 mod 7", 2},
+	} {
+		_, err := Eval(tc.expr, nil)
+		if tc.offset < 0 {
+			if err != ErrDivByZero {
+				t.Errorf("%q: got error %v, want ErrDivByZero", tc.expr, err)
+			}
+			continue
+		}
+		if e, ok := err.(*EvalError); !ok || e.Offset != tc.offset {
+			t.Errorf("%q: got error %v, want EvalError at offset %d", tc.expr, err, tc.offset)
+		}
+	}
+}
+
+func TestEvalRandom(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		ba, a, _ := randNums()
+		bb, b, _ := randNums()
+		bm, m, _ := randNums()
+		if m.IsZero() {
+			continue
+		}
+		vars := map[string]*Int{"a": a, "b": b, "m": m}
+
+		got, err := Eval("(a * b + a - b) mod m", vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := new(big.Int).Mul(ba, bb)
+		want.Add(want, ba).Sub(want, bb).Mod(want, bm)
+		requireEq(t, want, got, "Eval mod")
+
+		got, err = Eval("a ** (b >> 240) mod m", vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireEq(t, new(big.Int).Exp(ba, new(big.Int).Rsh(bb, 240), bm), got, "Eval exp mod")
+	}
+}
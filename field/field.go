@@ -0,0 +1,96 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package field provides arithmetic in prime fields of up to 256 bits,
+// built on uint256.Int.
+//
+// A PrimeField picks the reduction for its modulus once, when it is
+// created: a dedicated reduction for primes such as the secp256k1 and P-256
+// field primes, Montgomery multiplication for exponentiation modulo other
+// odd primes, and Barrett reduction for the remaining products. All methods
+// accept any uint256.Int and return reduced results.
+package field
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+var ErrNotPrime = errors.New("field: modulus is not prime")
+
+// PrimeField is the field of integers modulo a prime p. A PrimeField is
+// immutable and safe for concurrent use.
+type PrimeField struct {
+	p   uint256.Int
+	mod *uint256.Modulus
+}
+
+// NewPrimeField returns the field of integers modulo the odd prime p. It
+// returns uint256.ErrZeroModulus if p == 0, uint256.ErrEvenModulus if p is
+// even, and ErrNotPrime if p fails a probabilistic primality test.
+func NewPrimeField(p *uint256.Int) (*PrimeField, error) {
+	if p.IsZero() {
+		return nil, uint256.ErrZeroModulus
+	}
+	if p[0]&1 == 0 {
+		return nil, uint256.ErrEvenModulus
+	}
+	if !p.ToBig().ProbablyPrime(20) {
+		return nil, ErrNotPrime
+	}
+	return &PrimeField{p: *p, mod: uint256.NewModulus(p)}, nil
+}
+
+// Modulus returns the prime p of the field.
+func (f *PrimeField) Modulus() *uint256.Int {
+	return f.p.Clone()
+}
+
+// Reduce sets z to x mod p and returns z.
+func (f *PrimeField) Reduce(z, x *uint256.Int) *uint256.Int {
+	return f.mod.Reduce(z, x)
+}
+
+// Add sets z to x+y mod p and returns z.
+func (f *PrimeField) Add(z, x, y *uint256.Int) *uint256.Int {
+	return f.mod.AddMod(z, x, y)
+}
+
+// Sub sets z to x-y mod p and returns z.
+func (f *PrimeField) Sub(z, x, y *uint256.Int) *uint256.Int {
+	return z.SubMod(x, y, &f.p)
+}
+
+// Neg sets z to -x mod p and returns z.
+func (f *PrimeField) Neg(z, x *uint256.Int) *uint256.Int {
+	return z.NegMod(x, &f.p)
+}
+
+// Mul sets z to x*y mod p and returns z.
+func (f *PrimeField) Mul(z, x, y *uint256.Int) *uint256.Int {
+	return f.mod.MulMod(z, x, y)
+}
+
+// Sqr sets z to x*x mod p and returns z.
+func (f *PrimeField) Sqr(z, x *uint256.Int) *uint256.Int {
+	return f.mod.SqrMod(z, x)
+}
+
+// Exp sets z to x**e mod p and returns z.
+func (f *PrimeField) Exp(z, x, e *uint256.Int) *uint256.Int {
+	return f.mod.ExpMod(z, x, e)
+}
+
+// Inv sets z to the inverse of x mod p, and returns z and true. If x is 0
+// mod p, it has no inverse; z is set to 0 and Inv returns false.
+func (f *PrimeField) Inv(z, x *uint256.Int) (*uint256.Int, bool) {
+	return z.ModInverse(x, &f.p)
+}
+
+// Sqrt sets z to a square root of x mod p, and returns z and true. If x is
+// not a square mod p, z is set to 0 and Sqrt returns false.
+func (f *PrimeField) Sqrt(z, x *uint256.Int) (*uint256.Int, bool) {
+	return z.ModSqrt(x, &f.p)
+}
@@ -0,0 +1,94 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package field
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func randInt(t *testing.T) *uint256.Int {
+	t.Helper()
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return new(uint256.Int).SetBytes(b)
+}
+
+func TestPrimeField(t *testing.T) {
+	for _, hex := range []string{
+		"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", // secp256k1, dedicated reduction
+		"0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47", // BN254, Montgomery
+		"0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", // BLS12-381 r, p = 1 mod 4
+		"0xffffffff00000001", // Goldilocks, small
+		"0x7",
+	} {
+		p, err := uint256.FromHex(hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := NewPrimeField(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bp := p.ToBig()
+		for i := 0; i < 50; i++ {
+			x, y := randInt(t), randInt(t)
+			bx, by := x.ToBig(), y.ToBig()
+			check := func(op string, got *uint256.Int, want *big.Int) {
+				t.Helper()
+				if got.ToBig().Cmp(want) != 0 {
+					t.Fatalf("%v(%x, %x) mod %x: got %x, want %x", op, x, y, p, got, want)
+				}
+			}
+			check("Add", f.Add(new(uint256.Int), x, y), new(big.Int).Mod(new(big.Int).Add(bx, by), bp))
+			check("Sub", f.Sub(new(uint256.Int), x, y), new(big.Int).Mod(new(big.Int).Sub(bx, by), bp))
+			check("Neg", f.Neg(new(uint256.Int), x), new(big.Int).Mod(new(big.Int).Neg(bx), bp))
+			check("Mul", f.Mul(new(uint256.Int), x, y), new(big.Int).Mod(new(big.Int).Mul(bx, by), bp))
+			check("Sqr", f.Sqr(new(uint256.Int), x), new(big.Int).Mod(new(big.Int).Mul(bx, bx), bp))
+			check("Exp", f.Exp(new(uint256.Int), x, y), new(big.Int).Exp(bx, by, bp))
+			check("Reduce", f.Reduce(new(uint256.Int), x), new(big.Int).Mod(bx, bp))
+
+			inv, ok := f.Inv(new(uint256.Int), x)
+			if want := new(big.Int).ModInverse(bx, bp); want != nil {
+				if !ok {
+					t.Fatalf("Inv(%x) mod %x: no inverse", x, p)
+				}
+				check("Inv", inv, want)
+			} else if ok {
+				t.Fatalf("Inv(%x) mod %x: got %x, want no inverse", x, p, inv)
+			}
+
+			root, ok := f.Sqrt(new(uint256.Int), x)
+			if want := new(big.Int).ModSqrt(bx, bp); (want != nil) != ok {
+				t.Fatalf("Sqrt(%x) mod %x: got ok = %v", x, p, ok)
+			} else if ok {
+				check("Sqrt squared", f.Sqr(root, root), new(big.Int).Mod(bx, bp))
+			}
+		}
+	}
+}
+
+func TestNewPrimeFieldInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		p    *uint256.Int
+		want error
+	}{
+		{new(uint256.Int), uint256.ErrZeroModulus},
+		{uint256.NewInt(1), ErrNotPrime},
+		{uint256.NewInt(2), uint256.ErrEvenModulus},
+		{uint256.NewInt(1 << 40), uint256.ErrEvenModulus},
+		{uint256.NewInt(91), ErrNotPrime},
+		{new(uint256.Int).SetAllOne(), ErrNotPrime},
+	} {
+		if _, err := NewPrimeField(tc.p); err != tc.want {
+			t.Errorf("NewPrimeField(%v): got error %v, want %v", tc.p, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// MaxFixedModuli is the number of moduli that can be pinned at once with
+// RegisterFixedModulus.
+const MaxFixedModuli = 4
+
+// ErrTooManyFixedModuli is returned by RegisterFixedModulus when
+// MaxFixedModuli moduli are already pinned.
+var ErrTooManyFixedModuli = errors.New("too many fixed moduli")
+
+var (
+	fixedMu sync.Mutex   // serializes registrations
+	fixed   atomic.Value // []*Modulus, the pinned moduli; never modified in place
+	// fixedCount is len(fixed), kept apart so that the hot paths of MulMod
+	// and ExpMod pay a single load when nothing is pinned.
+	fixedCount int32
+)
+
+// RegisterFixedModulus precomputes a Modulus for m and pins it, so that
+// the MulMod, SqrMod, ExpMod, ExpModCT and InvModPrime methods of Int use it
+// whenever they are called with a modulus equal to m, without any setup per
+// call. For InvModPrime, an addition chain for m-2 is precomputed.
+// Up to MaxFixedModuli moduli can be pinned, for example both the base and
+// the scalar field of a curve; beyond that ErrTooManyFixedModuli is
+// returned. Registering a pinned modulus again has no effect. It returns
+// ErrZeroModulus if m == 0.
+func RegisterFixedModulus(m *Int) error {
+	if m.IsZero() {
+		return ErrZeroModulus
+	}
+	fixedMu.Lock()
+	defer fixedMu.Unlock()
+	if fixedModulus(m) != nil {
+		return nil
+	}
+	mods, _ := fixed.Load().([]*Modulus)
+	if len(mods) == MaxFixedModuli {
+		return ErrTooManyFixedModuli
+	}
+	mod := NewModulus(m)
+	if !m.LtUint64(3) {
+		mod.inv = NewAddChain(new(Int).SubUint64(m, 2))
+	}
+	fixed.Store(append(mods[:len(mods):len(mods)], mod))
+	atomic.StoreInt32(&fixedCount, int32(len(mods)+1))
+	return nil
+}
+
+// UnregisterFixedModulus unpins m, if it was registered with
+// RegisterFixedModulus.
+func UnregisterFixedModulus(m *Int) {
+	fixedMu.Lock()
+	defer fixedMu.Unlock()
+	mods, _ := fixed.Load().([]*Modulus)
+	kept := make([]*Modulus, 0, len(mods))
+	for _, mod := range mods {
+		if !mod.m.Eq(m) {
+			kept = append(kept, mod)
+		}
+	}
+	fixed.Store(kept)
+	atomic.StoreInt32(&fixedCount, int32(len(kept)))
+}
+
+// fixedModulus returns the pinned Modulus for m, or nil if m is not pinned.
+// It is small enough to be inlined, so that the common case of no pinned
+// moduli costs one load and a branch.
+func fixedModulus(m *Int) *Modulus {
+	if atomic.LoadInt32(&fixedCount) == 0 {
+		return nil
+	}
+	return lookupFixed(m)
+}
+
+// lookupFixed searches the pinned moduli for m.
+func lookupFixed(m *Int) *Modulus {
+	mods, _ := fixed.Load().([]*Modulus)
+	for _, mod := range mods {
+		if mod.m.Eq(m) {
+			return mod
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestRegisterFixedModulus(t *testing.T) {
+	m := mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	other := mustHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	if err := RegisterFixedModulus(new(Int)); err != ErrZeroModulus {
+		t.Errorf("RegisterFixedModulus(0): got %v, want ErrZeroModulus", err)
+	}
+	if err := RegisterFixedModulus(m); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterFixedModulus(m)
+	if fixedModulus(m) == nil || fixedModulus(other) != nil {
+		t.Fatalf("fixedModulus: pinned modulus not found, or other one found")
+	}
+	bm := m.ToBig()
+	for i := 0; i < 100; i++ {
+		bx, x, _ := randHighNums()
+		by, y, _ := randHighNums()
+		requireEq(t, new(big.Int).Mod(new(big.Int).Mul(bx, by), bm), new(Int).MulMod(x, y, m), "MulMod")
+		requireEq(t, new(big.Int).Mod(new(big.Int).Mul(bx, bx), bm), new(Int).SqrMod(x, m), "SqrMod")
+		if i < 5 {
+			requireEq(t, new(big.Int).Exp(bx, by, bm), new(Int).ExpMod(x, y, m), "ExpMod")
+			requireEq(t, new(big.Int).Exp(bx, by, bm), new(Int).ExpModCT(x, y, m), "ExpModCT")
+		}
+	}
+	// Several moduli can be pinned at once.
+	if err := RegisterFixedModulus(other); err != nil {
+		t.Fatal(err)
+	}
+	if fixedModulus(m) == nil || fixedModulus(other) == nil {
+		t.Errorf("RegisterFixedModulus did not keep both moduli")
+	}
+	if err := RegisterFixedModulus(m); err != nil {
+		t.Errorf("registering a pinned modulus again: %v", err)
+	}
+	var extra []*Int
+	for i := uint64(0); i < MaxFixedModuli-2; i++ {
+		e := NewInt(1000 + i)
+		extra = append(extra, e)
+		if err := RegisterFixedModulus(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RegisterFixedModulus(NewInt(7)); err != ErrTooManyFixedModuli {
+		t.Errorf("registering too many moduli: got %v, want ErrTooManyFixedModuli", err)
+	}
+	for _, e := range extra {
+		if got := new(Int).MulMod(NewInt(999), NewInt(999), e); got.Uint64() != 999*999%e.Uint64() {
+			t.Errorf("MulMod with pinned %v: got %v", e, got)
+		}
+		UnregisterFixedModulus(e)
+	}
+	UnregisterFixedModulus(m)
+	if fixedModulus(m) != nil || fixedModulus(other) == nil {
+		t.Errorf("UnregisterFixedModulus removed the wrong modulus")
+	}
+	UnregisterFixedModulus(other)
+	if fixedModulus(other) != nil {
+		t.Errorf("UnregisterFixedModulus did not remove the modulus")
+	}
+}
+
+func TestFixedInvModPrime(t *testing.T) {
+	for _, p := range []*Int{
+		mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47"),
+		mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		NewInt(0xffffffff00000001),
+		NewInt(3),
+		NewInt(2),
+	} {
+		bp := p.ToBig()
+		check := func(x *Int, pinned bool) {
+			t.Helper()
+			want := new(big.Int).ModInverse(x.ToBig(), bp)
+			if want == nil {
+				want = new(big.Int)
+			}
+			requireEq(t, want, new(Int).InvModPrime(x, p), fmt.Sprintf("InvModPrime(%x, %x), pinned: %v", x, p, pinned))
+		}
+		xs := []*Int{new(Int), NewInt(1), NewInt(2), NewInt(3), NewInt(4), new(Int).SetAllOne()}
+		for i := 0; i < 10; i++ {
+			_, x, _ := randHighNums()
+			xs = append(xs, x)
+		}
+		for _, x := range xs {
+			check(x, false)
+		}
+		if err := RegisterFixedModulus(p); err != nil {
+			t.Fatal(err)
+		}
+		if fixedModulus(p).inv == nil && p.GtUint64(2) {
+			t.Errorf("RegisterFixedModulus(%x) did not precompute the inversion chain", p)
+		}
+		for _, x := range xs {
+			check(x, true)
+		}
+		UnregisterFixedModulus(p)
+	}
+	for _, p := range []*Int{new(Int), NewInt(1)} {
+		if got := new(Int).InvModPrime(NewInt(5), p); !got.IsZero() {
+			t.Errorf("InvModPrime(5, %v): got %v, want 0", p, got)
+		}
+	}
+}
+
+func BenchmarkFixedInvModPrime(b *testing.B) {
+	p := mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	x := mustHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	b.Run("unpinned", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.InvModPrime(x, p)
+		}
+	})
+	b.Run("pinned", func(b *testing.B) {
+		if err := RegisterFixedModulus(p); err != nil {
+			b.Fatal(err)
+		}
+		defer UnregisterFixedModulus(p)
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.InvModPrime(x, p)
+		}
+	})
+	b.Run("ModInverseCT", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ModInverseCT(x, p)
+		}
+	})
+}
+
+func BenchmarkFixedModulus(b *testing.B) {
+	m := mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	x := mustHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	b.Run("MulMod", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.MulMod(x, x, m)
+		}
+	})
+	if err := RegisterFixedModulus(m); err != nil {
+		b.Fatal(err)
+	}
+	defer UnregisterFixedModulus(m)
+	b.Run("MulMod/fixed", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.MulMod(x, x, m)
+		}
+	})
+}
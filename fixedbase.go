@@ -0,0 +1,55 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// FixedBase holds precomputed powers of a base modulo m, for computing many
+// powers of the same base, such as g**x for a fixed generator g. The table
+// holds base**(j * 16**i) for every 4-bit digit j and position i, so that
+// Exp needs at most 64 multiplications and no squarings. It takes 32 KiB.
+// A FixedBase is immutable and safe for concurrent use.
+type FixedBase struct {
+	mod   *Modulus
+	d     expDomain
+	table *[64][16]Int
+}
+
+// NewFixedBase returns a FixedBase for base modulo m.
+func NewFixedBase(base, m *Int) *FixedBase {
+	fb := &FixedBase{mod: NewModulus(m)}
+	if m.IsZero() {
+		return fb
+	}
+	fb.d = fb.mod.expDomain()
+	fb.table = new([64][16]Int)
+	var b Int
+	fb.mod.Reduce(&b, base)
+	fb.d.enter(&b, &b)
+	for i := range fb.table {
+		t := &fb.table[i]
+		t[0], t[1] = fb.d.one, b
+		for j := 2; j < len(t); j++ {
+			fb.d.mul(&t[j], &t[j-1], &b)
+		}
+		// b**(16**(i+1)) = b**(15 * 16**i) * b**(16**i)
+		fb.d.mul(&b, &t[15], &b)
+	}
+	return fb
+}
+
+// Exp sets z to base**e mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (fb *FixedBase) Exp(z, e *Int) *Int {
+	if fb.table == nil {
+		return z.Clear()
+	}
+	res := fb.d.one
+	for i := range fb.table {
+		if digit := (e[i/16] >> uint(i%16*4)) & 0xf; digit != 0 {
+			fb.d.mul(&res, &res, &fb.table[i][digit])
+		}
+	}
+	fb.d.leave(&res)
+	return z.Set(&res)
+}
@@ -0,0 +1,50 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestFixedBase(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		bb, b, _ := randHighNums()
+		fb := NewFixedBase(b, m)
+		exps := []*Int{new(Int), NewInt(1), NewInt(16), new(Int).SetAllOne()}
+		for i := 0; i < 20; i++ {
+			_, e, _ := randNums()
+			exps = append(exps, e)
+		}
+		for _, e := range exps {
+			want := new(big.Int).Exp(bb, e.ToBig(), bm)
+			requireEq(t, want, fb.Exp(new(Int), e), fmt.Sprintf("FixedBase(%x, %x).Exp(%x)", b, m, e))
+		}
+	}
+	if got := NewFixedBase(NewInt(3), new(Int)).Exp(new(Int), NewInt(5)); !got.IsZero() {
+		t.Errorf("FixedBase with zero modulus: got %v, want 0", got)
+	}
+}
+
+func BenchmarkFixedBase(b *testing.B) {
+	m, _ := FromHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	g, _ := FromHex("0x2b8cd3f0f2e54d0a6c7cb2a1d8c14c4df6f3b15e2ed4f0b4bbf1bc2bb5ea8d1b")
+	_, e, _ := randHighNums()
+	b.Run("FixedBase", func(b *testing.B) {
+		fb := NewFixedBase(g, m)
+		var z Int
+		for i := 0; i < b.N; i++ {
+			fb.Exp(&z, e)
+		}
+	})
+	b.Run("ExpMod", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ExpMod(g, e, m)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "math/bits"
+
+// GCD sets z to the greatest common divisor of x and y, and returns z. It
+// uses Lehmer's algorithm: the leading 64 bits of the operands drive a run
+// of single-word Euclidean steps, which are then applied to the full values
+// at once. The gcd of 0 and 0 is 0.
+func (z *Int) GCD(x, y *Int) *Int {
+	var l lehmer
+	l.init(x, y, false)
+	l.run()
+	return z.Set(&l.a)
+}
+
+// ExtGCD returns the greatest common divisor g of x and y, together with
+// Bézout coefficients s and t such that s*x + t*y = g. The coefficients are
+// two's complement signed integers, as used by the signed methods such as
+// SMod. Their magnitudes are at most max(x, y) / (2*g), or 1 if that is
+// smaller, so they always fit. If x == y == 0, all results are 0.
+func ExtGCD(x, y *Int) (g, s, t Int) {
+	if x.IsZero() && y.IsZero() {
+		return g, s, t
+	}
+	var l lehmer
+	l.init(x, y, true)
+	l.run()
+	if l.swapped {
+		return l.a, l.va, l.ua
+	}
+	return l.a, l.ua, l.va
+}
+
+// lehmer holds the state of Lehmer's gcd algorithm on a >= b. With
+// extended set, it maintains a = ua*x + va*y and b = ub*x + vb*y, with the
+// cofactors in two's complement; x and y are swapped if x < y.
+type lehmer struct {
+	a, b           Int
+	ua, va, ub, vb Int
+	extended       bool
+	swapped        bool
+}
+
+func (l *lehmer) init(x, y *Int, extended bool) {
+	l.a, l.b = *x, *y
+	if l.a.Lt(&l.b) {
+		l.a, l.b = l.b, l.a
+		l.swapped = true
+	}
+	l.extended = extended
+	l.ua, l.vb = Int{1}, Int{1}
+}
+
+func (l *lehmer) run() {
+	for l.b[3]|l.b[2]|l.b[1] != 0 {
+		u0, u1, v0, v1, even := lehmerSimulate(&l.a, &l.b)
+		if v0 != 0 {
+			l.update(u0, u1, v0, v1, even)
+		} else {
+			// The quotient does not fit in a word; take a full division step.
+			l.euclidStep()
+		}
+	}
+	if l.extended {
+		for !l.b.IsZero() {
+			l.euclidStep()
+		}
+		return
+	}
+	// Finish with single-word arithmetic.
+	if !l.b.IsZero() {
+		l.euclidStep()
+		a, b := l.a[0], l.b[0]
+		for b != 0 {
+			a, b = b, a%b
+		}
+		l.a.SetUint64(a)
+	}
+}
+
+// lehmerSimulate computes the cosequences of a run of Euclidean steps on
+// the leading 64 bits of a and b, for a >= b >= 2**64, stopping by Collins'
+// condition while the steps are certain to match those on the full values.
+// This is the algorithm of math/big. The signs of the cosequences alternate:
+// if even is set, u0 and v1 are non-negative and u1 and v0 non-positive,
+// and the reverse otherwise.
+func lehmerSimulate(a, b *Int) (u0, u1, v0, v1 uint64, even bool) {
+	n := 3
+	for a[n] == 0 {
+		n--
+	}
+	h := uint(bits.LeadingZeros64(a[n]))
+	a1 := a[n]<<h | a[n-1]>>(64-h)
+	var a2 uint64
+	switch {
+	case b[n] != 0:
+		a2 = b[n]<<h | b[n-1]>>(64-h)
+	case b[n-1] != 0:
+		a2 = b[n-1] >> (64 - h)
+	}
+
+	var u2, v2 uint64
+	u0, u1, u2 = 0, 1, 0
+	v0, v1, v2 = 0, 0, 1
+	for a2 >= v2 && a1-a2 >= v1+v2 {
+		q, r := a1/a2, a1%a2
+		a1, a2 = a2, r
+		u0, u1, u2 = u1, u2, u1+q*u2
+		v0, v1, v2 = v1, v2, v1+q*v2
+		even = !even
+	}
+	return u0, u1, v0, v1, even
+}
+
+// update applies the cosequences from lehmerSimulate to a, b and their
+// cofactors.
+func (l *lehmer) update(u0, u1, v0, v1 uint64, even bool) {
+	a := lehmerComb(&l.a, &l.b, u0, v0, !even)
+	b := lehmerComb(&l.a, &l.b, u1, v1, even)
+	l.a, l.b = a, b
+	if l.extended {
+		ua := lehmerComb(&l.ua, &l.ub, u0, v0, !even)
+		ub := lehmerComb(&l.ua, &l.ub, u1, v1, even)
+		va := lehmerComb(&l.va, &l.vb, u0, v0, !even)
+		vb := lehmerComb(&l.va, &l.vb, u1, v1, even)
+		l.ua, l.ub, l.va, l.vb = ua, ub, va, vb
+	}
+}
+
+// lehmerComb returns cy*y - cx*x if negX is set, and cx*x - cy*y otherwise.
+// The products may exceed 256 bits, but the result fits, so wrapping
+// arithmetic gives it exactly.
+func lehmerComb(x, y *Int, cx, cy uint64, negX bool) Int {
+	var p, q Int
+	p.Mul(x, &Int{cx})
+	q.Mul(y, &Int{cy})
+	if negX {
+		return *p.Sub(&q, &p)
+	}
+	return *p.Sub(&p, &q)
+}
+
+// euclidStep replaces a, b by b, a mod b.
+func (l *lehmer) euclidStep() {
+	var quot [4]uint64
+	r := udivrem(quot[:], l.a[:], &l.b)
+	q := Int(quot)
+	var t Int
+	l.a, l.b = l.b, r
+	if l.extended {
+		t.Sub(&l.ua, t.Mul(&q, &l.ub))
+		l.ua, l.ub = l.ub, t
+		t.Sub(&l.va, t.Mul(&q, &l.vb))
+		l.va, l.vb = l.vb, t
+	}
+}
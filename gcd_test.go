@@ -0,0 +1,115 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// toSignedBig returns x interpreted as a two's complement signed integer.
+func toSignedBig(x *Int) *big.Int {
+	b := x.ToBig()
+	if x.Sign() < 0 {
+		b.Sub(b, bigtt256)
+	}
+	return b
+}
+
+func TestGCD(t *testing.T) {
+	check := func(x, y *Int) {
+		t.Helper()
+		bx, by := x.ToBig(), y.ToBig()
+		want := new(big.Int).GCD(nil, nil, bx, by)
+		requireEq(t, want, new(Int).GCD(x, y), "GCD")
+		g, s, tt := ExtGCD(x, y)
+		requireEq(t, want, &g, "ExtGCD")
+		bs, bt := toSignedBig(&s), toSignedBig(&tt)
+		sum := new(big.Int).Mul(bs, bx)
+		sum.Add(sum, new(big.Int).Mul(bt, by))
+		if sum.Cmp(want) != 0 {
+			t.Fatalf("ExtGCD(%x, %x): %v*x + %v*y = %x, want %x", x, y, bs, bt, sum, want)
+		}
+		if want.Sign() == 0 && (!s.IsZero() || !tt.IsZero()) {
+			t.Fatalf("ExtGCD(0, 0): got coefficients %v, %v, want 0, 0", bs, bt)
+		}
+		if want.Sign() != 0 {
+			bound := new(big.Int).Set(bx)
+			if by.Cmp(bx) > 0 {
+				bound.Set(by)
+			}
+			bound.Quo(bound, new(big.Int).Lsh(want, 1))
+			if bound.Sign() == 0 {
+				bound.SetInt64(1)
+			}
+			if bs.CmpAbs(bound) > 0 || bt.CmpAbs(bound) > 0 {
+				t.Fatalf("ExtGCD(%x, %x): coefficients %v, %v exceed %v", x, y, bs, bt, bound)
+			}
+		}
+	}
+	ints := []*Int{new(Int), NewInt(1), NewInt(6), NewInt(1 << 63), new(Int).SetAllOne(), {0, 1}, {0, 0, 0, 1 << 63}}
+	for _, x := range ints {
+		for _, y := range ints {
+			check(x, y)
+		}
+	}
+	// Consecutive Fibonacci numbers are the worst case for Euclid.
+	a, b := NewInt(0), NewInt(1)
+	for {
+		if _, overflow := new(Int).AddOverflow(a, b); overflow {
+			break
+		}
+		a, b = b, new(Int).Add(a, b)
+	}
+	check(a, b)
+	for i := 0; i < 2000; i++ {
+		_, x, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, y, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%3 == 0 {
+			// Give x and y a large common factor.
+			_, f, _ := randNums()
+			f.Rsh(f, 128)
+			x.Mul(x.Rsh(x, 128), f)
+			y.Mul(y.Rsh(y, 130), f)
+		}
+		check(x, y)
+	}
+}
+
+func BenchmarkGCD(b *testing.B) {
+	x := mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	y := mustHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	b.Run("Lehmer", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.GCD(x, y)
+		}
+	})
+	b.Run("ExtGCD", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ExtGCD(x, y)
+		}
+	})
+	b.Run("big", func(b *testing.B) {
+		bx, by := x.ToBig(), y.ToBig()
+		z := new(big.Int)
+		for i := 0; i < b.N; i++ {
+			z.GCD(nil, nil, bx, by)
+		}
+	})
+	b.Run("big/ext", func(b *testing.B) {
+		bx, by := x.ToBig(), y.ToBig()
+		z, s, t := new(big.Int), new(big.Int), new(big.Int)
+		for i := 0; i < b.N; i++ {
+			z.GCD(s, t, bx, by)
+		}
+	})
+}
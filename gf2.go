@@ -0,0 +1,60 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// GF2Field is the binary field GF(2^256), with elements represented as
+// polynomials over GF(2) of degree below 256 (bit i is the coefficient of
+// x^i), reduced modulo an irreducible polynomial x^256 + r(x).
+// Addition and subtraction in the field are both Xor.
+type GF2Field struct {
+	r Int
+}
+
+// NewGF2Field returns the field defined by the polynomial x^256 + r(x).
+// The polynomial must be irreducible for Inv to be meaningful; this is not
+// checked. A common choice is r = x^10 + x^5 + x^2 + 1 (0x425).
+func NewGF2Field(r *Int) *GF2Field {
+	return &GF2Field{r: *r}
+}
+
+// reduce returns hi*x^256 + lo modulo x^256 + r.
+func (f *GF2Field) reduce(hi, lo Int) Int {
+	// x^256 = r, so hi*x^256 + lo = hi*r + lo. Each round lowers the degree
+	// of the high half by 256 - deg(r).
+	for !hi.IsZero() {
+		h, l := Clmul(&hi, &f.r)
+		lo.Xor(&lo, &l)
+		hi = h
+	}
+	return lo
+}
+
+// Mul sets z to the field product x*y and returns z.
+func (f *GF2Field) Mul(z, x, y *Int) *Int {
+	hi, lo := Clmul(x, y)
+	res := f.reduce(hi, lo)
+	return z.Set(&res)
+}
+
+// Sqr sets z to x*x and returns z.
+func (f *GF2Field) Sqr(z, x *Int) *Int {
+	return f.Mul(z, x, x)
+}
+
+// Inv sets z to the multiplicative inverse of x and returns z.
+// It computes x^(2^256-2) = x^2 * x^4 * ... * x^(2^255), which takes a
+// fixed number of multiplications. If x == 0, z is set to 0.
+func (f *GF2Field) Inv(z, x *Int) *Int {
+	t := *x
+	res := Int{1}
+	for i := 1; i < 256; i++ {
+		f.Sqr(&t, &t)
+		f.Mul(&res, &res, &t)
+	}
+	if x.IsZero() {
+		return z.Clear()
+	}
+	return z.Set(&res)
+}
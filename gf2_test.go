@@ -0,0 +1,52 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// polyModRef reduces the GF(2) polynomial a modulo m.
+func polyModRef(a, m *big.Int) *big.Int {
+	a = new(big.Int).Set(a)
+	for a.BitLen() >= m.BitLen() {
+		a.Xor(a, new(big.Int).Lsh(m, uint(a.BitLen()-m.BitLen())))
+	}
+	return a
+}
+
+func TestGF2Field(t *testing.T) {
+	for _, rv := range []string{"0x425", "0x1000000000000000000000000000000000000000000000000000000000000425"} {
+		r, _ := FromHex(rv)
+		f := NewGF2Field(r)
+		m := new(big.Int).Or(bigtt256, r.ToBig())
+		for i := 0; i < 100; i++ {
+			b1, f1, _ := randNums()
+			b2, f2, _ := randNums()
+			want := polyModRef(clmulRef(b1, b2), m)
+			requireEq(t, want, f.Mul(new(Int), f1, f2), "GF2Field.Mul")
+			// Aliasing.
+			requireEq(t, want, f.Mul(f1, f1, f2), "GF2Field.Mul aliased")
+		}
+	}
+	f := NewGF2Field(NewInt(0x425))
+	for i := 0; i < 20; i++ {
+		_, x, _ := randHighNums()
+		if x.IsZero() {
+			continue
+		}
+		inv := f.Inv(new(Int), x)
+		if got := f.Mul(new(Int), x, inv); !got.Eq(NewInt(1)) {
+			t.Fatalf("x * x^-1 = %x for x = %x", got, x)
+		}
+	}
+	if got := f.Inv(new(Int), new(Int)); !got.IsZero() {
+		t.Errorf("Inv(0): got %x", got)
+	}
+	if got := f.Inv(new(Int), NewInt(1)); !got.Eq(NewInt(1)) {
+		t.Errorf("Inv(1): got %x", got)
+	}
+}
@@ -0,0 +1,93 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrIDLayout     = errors.New("invalid id layout")
+	ErrIDFieldRange = errors.New("id field value too large")
+)
+
+// IDLayout describes a structured 256-bit identifier made of bit fields.
+// From the most significant end, an id holds a timestamp, a shard number,
+// and random payload filling the remaining bits, so ids order by time.
+type IDLayout struct {
+	TimestampBits uint // at most 64
+	ShardBits     uint // at most 64
+}
+
+// RandomBits returns the number of bits left for the random payload.
+func (l IDLayout) RandomBits() uint {
+	return 256 - l.TimestampBits - l.ShardBits
+}
+
+// Validate returns ErrIDLayout if the fields do not fit the layout rules.
+func (l IDLayout) Validate() error {
+	if l.TimestampBits > 64 || l.ShardBits > 64 {
+		return ErrIDLayout
+	}
+	return nil
+}
+
+// fits reports whether v fits in n bits.
+func fits(v uint64, n uint) bool {
+	return n >= 64 || v>>n == 0
+}
+
+// Pack sets z to the id made of the given fields and returns z.
+// It returns ErrIDFieldRange if a field does not fit its width.
+func (l IDLayout) Pack(z *Int, timestamp, shard uint64, random *Int) (*Int, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	if !fits(timestamp, l.TimestampBits) || !fits(shard, l.ShardBits) || random.BitLen() > int(l.RandomBits()) {
+		return nil, ErrIDFieldRange
+	}
+	var id, t Int
+	id.Set(random)
+	id.Or(&id, t.Lsh(t.SetUint64(shard), l.RandomBits()))
+	id.Or(&id, t.Lsh(t.SetUint64(timestamp), l.RandomBits()+l.ShardBits))
+	return z.Set(&id), nil
+}
+
+// Unpack splits id into its fields.
+func (l IDLayout) Unpack(id *Int) (timestamp, shard uint64, random Int, err error) {
+	if err := l.Validate(); err != nil {
+		return 0, 0, random, err
+	}
+	var t, mask Int
+	t.Rsh(id, l.RandomBits())
+	shard = t.Uint64()
+	if l.ShardBits < 64 {
+		shard &= 1<<l.ShardBits - 1
+	}
+	t.Rsh(id, l.RandomBits()+l.ShardBits)
+	timestamp = t.Uint64()
+
+	mask.Lsh(mask.SetOne(), l.RandomBits())
+	mask.SubUint64(&mask, 1)
+	random.And(id, &mask)
+	return timestamp, shard, random, nil
+}
+
+// New sets z to an id with the given timestamp and shard, and a random
+// payload read from r (typically crypto/rand.Reader), and returns z.
+func (l IDLayout) New(z *Int, timestamp, shard uint64, r io.Reader) (*Int, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	var buf [32]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	var random Int
+	random.SetBytes32(buf[:])
+	random.Rsh(&random, l.TimestampBits+l.ShardBits)
+	return l.Pack(z, timestamp, shard, &random)
+}
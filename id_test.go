@@ -0,0 +1,75 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestIDLayout(t *testing.T) {
+	layouts := []IDLayout{{48, 16}, {64, 64}, {0, 0}, {64, 0}, {1, 7}}
+	for _, l := range layouts {
+		for i := 0; i < 100; i++ {
+			ts := uint64(i) * 0x9e3779b97f4a7c15
+			shard := uint64(i) * 0x2545f4914f6cdd1d
+			if l.TimestampBits < 64 {
+				ts &= 1<<l.TimestampBits - 1
+			}
+			if l.ShardBits < 64 {
+				shard &= 1<<l.ShardBits - 1
+			}
+			id, err := l.New(new(Int), ts, shard, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotTs, gotShard, random, err := l.Unpack(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotTs != ts || gotShard != shard {
+				t.Fatalf("layout %v: got (%d, %d), want (%d, %d)", l, gotTs, gotShard, ts, shard)
+			}
+			if random.BitLen() > int(l.RandomBits()) {
+				t.Fatalf("layout %v: random payload too wide: %x", l, &random)
+			}
+			back, err := l.Pack(new(Int), gotTs, gotShard, &random)
+			if err != nil || !back.Eq(id) {
+				t.Fatalf("layout %v: repack mismatch: %x != %x (%v)", l, back, id, err)
+			}
+		}
+	}
+	// Ids order by timestamp.
+	l := IDLayout{48, 16}
+	ones := bytes.Repeat([]byte{0xff}, 32)
+	a, _ := l.New(new(Int), 1000, 65535, bytes.NewReader(ones))
+	b, _ := l.New(new(Int), 1001, 0, bytes.NewReader(make([]byte, 32)))
+	if !a.Lt(b) {
+		t.Errorf("ids not ordered by time: %x >= %x", a, b)
+	}
+}
+
+func TestIDLayoutErrors(t *testing.T) {
+	if _, err := (IDLayout{65, 0}).Pack(new(Int), 0, 0, new(Int)); err != ErrIDLayout {
+		t.Errorf("got %v, want ErrIDLayout", err)
+	}
+	if _, _, _, err := (IDLayout{0, 65}).Unpack(new(Int)); err != ErrIDLayout {
+		t.Errorf("got %v, want ErrIDLayout", err)
+	}
+	l := IDLayout{48, 16}
+	if _, err := l.Pack(new(Int), 1<<48, 0, new(Int)); err != ErrIDFieldRange {
+		t.Errorf("got %v, want ErrIDFieldRange", err)
+	}
+	if _, err := l.Pack(new(Int), 0, 1<<16, new(Int)); err != ErrIDFieldRange {
+		t.Errorf("got %v, want ErrIDFieldRange", err)
+	}
+	if _, err := l.Pack(new(Int), 0, 0, new(Int).Lsh(NewInt(1), 192)); err != ErrIDFieldRange {
+		t.Errorf("got %v, want ErrIDFieldRange", err)
+	}
+	if _, err := l.New(new(Int), 0, 0, bytes.NewReader(nil)); err == nil {
+		t.Error("expected error from short reader")
+	}
+}
@@ -0,0 +1,17 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !uint256trace
+// +build !uint256trace
+
+package uint256
+
+// instrumented reports whether the package was built with the uint256trace
+// tag. Instrumentation hooks are guarded by it, so they compile away
+// entirely in normal builds.
+const instrumented = false
+
+func region(name string) func() {
+	return func() {}
+}
@@ -0,0 +1,28 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build uint256trace
+// +build uint256trace
+
+package uint256
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// instrumented reports whether the package was built with the uint256trace
+// tag, which enables instrumentation of expensive operations: each one runs
+// inside a runtime/trace region named "uint256.<op>".
+//
+// The pprof labels of the calling goroutine are left alone, so CPU profiles
+// keep the caller's own attribution. Callers that want samples labelled per
+// operation can wrap the calls in pprof.Do.
+const instrumented = true
+
+// region starts instrumentation of the named operation, and returns a
+// function ending it.
+func region(name string) func() {
+	return trace.StartRegion(context.Background(), "uint256."+name).End
+}
@@ -0,0 +1,43 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build uint256trace
+// +build uint256trace
+
+package uint256
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+)
+
+func TestInstrumentTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("tracing unavailable: %v", err)
+	}
+	p := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	Reciprocal(p)
+	NewModulus(p)
+	x := []Int{{3}, {4}}
+	MulModBatch(make([]Int, 2), x, x, p)
+	ExpModBatch(make([]Int, 2), x, x, p)
+	NTT([]Int{{1}, {2}}, new(Int).SubUint64(p, 1), p)
+	EvalPolyModBatch(x, x, p)
+	NewAddChain(NewInt(5)).Exp(new(Int), NewInt(3), p)
+	IsQRBatch([]Int{{4}}, p)
+	new(Int).ExpMod(NewInt(3), NewInt(5), p)
+	trace.Stop()
+
+	for _, name := range []string{
+		"uint256.Reciprocal", "uint256.NewModulus", "uint256.MulModBatch",
+		"uint256.ExpModBatch", "uint256.NTT", "uint256.EvalPolyModBatch",
+		"uint256.AddChain.Exp", "uint256.IsQRBatch", "uint256.ExpMod",
+	} {
+		if !bytes.Contains(buf.Bytes(), []byte(name)) {
+			t.Errorf("trace has no %s region", name)
+		}
+	}
+}
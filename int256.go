@@ -0,0 +1,216 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "math/big"
+
+// Int256 is a signed 256-bit integer in two's complement, ranging from
+// -2**255 to 2**255-1. It shares the representation of Int, so the two
+// convert freely, but keeps signed and unsigned quantities apart in the
+// type system. Arithmetic wraps around modulo 2**256, as with Int.
+type Int256 Int
+
+// NewInt256 returns a new Int256 set to v.
+func NewInt256(v int64) *Int256 {
+	return new(Int256).SetInt64(v)
+}
+
+// Int256FromBig converts b to an Int256, and returns it and whether b was
+// outside the range of Int256, in which case it is truncated to 256 bits.
+func Int256FromBig(b *big.Int) (*Int256, bool) {
+	var abs Int
+	overflow := abs.SetFromBig(new(big.Int).Abs(b))
+	limit := 255
+	if b.Sign() < 0 {
+		abs.Neg(&abs)
+		// -2**255 is in range, the one negative number without a positive
+		// counterpart.
+		if b.BitLen() == 256 && b.TrailingZeroBits() == 255 {
+			limit = 256
+		}
+	}
+	z := Int256(abs)
+	return &z, overflow || b.BitLen() > limit
+}
+
+// SetInt64 sets z to v and returns z.
+func (z *Int256) SetInt64(v int64) *Int256 {
+	s := uint64(v >> 63) // all ones if v is negative
+	z[0], z[1], z[2], z[3] = uint64(v), s, s, s
+	return z
+}
+
+// SetUnsigned sets z to the bits of x, reinterpreted as two's complement,
+// and returns z.
+func (z *Int256) SetUnsigned(x *Int) *Int256 {
+	*z = Int256(*x)
+	return z
+}
+
+// Unsigned returns z reinterpreted as an Int. The result shares z's
+// storage.
+func (z *Int256) Unsigned() *Int {
+	return (*Int)(z)
+}
+
+// Set sets z to x and returns z.
+func (z *Int256) Set(x *Int256) *Int256 {
+	*z = *x
+	return z
+}
+
+// Int64 returns the lower 64 bits of z as an int64.
+func (z *Int256) Int64() int64 {
+	return int64(z[0])
+}
+
+// IsInt64 reports whether z can be represented as an int64.
+func (z *Int256) IsInt64() bool {
+	s := uint64(int64(z[0]) >> 63)
+	return z[1] == s && z[2] == s && z[3] == s
+}
+
+// Sign returns -1 if z < 0, 0 if z == 0 and +1 if z > 0.
+func (z *Int256) Sign() int {
+	return z.Unsigned().Sign()
+}
+
+// Cmp compares z and x and returns -1 if z < x, 0 if z == x and +1 if
+// z > x.
+func (z *Int256) Cmp(x *Int256) int {
+	switch {
+	case z.Unsigned().Slt(x.Unsigned()):
+		return -1
+	case z.Unsigned().Sgt(x.Unsigned()):
+		return 1
+	}
+	return 0
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Int256) Add(x, y *Int256) *Int256 {
+	z.Unsigned().Add(x.Unsigned(), y.Unsigned())
+	return z
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Int256) Sub(x, y *Int256) *Int256 {
+	z.Unsigned().Sub(x.Unsigned(), y.Unsigned())
+	return z
+}
+
+// Mul sets z to the product x*y and returns z.
+func (z *Int256) Mul(x, y *Int256) *Int256 {
+	z.Unsigned().Mul(x.Unsigned(), y.Unsigned())
+	return z
+}
+
+// Div sets z to the quotient x/y, truncated toward zero, and returns z.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int256) Div(x, y *Int256) *Int256 {
+	z.Unsigned().SDiv(x.Unsigned(), y.Unsigned())
+	return z
+}
+
+// Signed division comes in three conventions, which agree when the
+// remainder is zero and otherwise differ in the sign of the remainder:
+//
+//	QuoRem       truncated (Go, EVM SDIV/SMOD): r has the sign of x
+//	DivModFloor  floored (Python):              r has the sign of y
+//	DivModEuclid Euclidean:                     r >= 0
+//
+// In all of them x = q*y + r with |r| < |y|. The quotient of -2**255 by -1
+// wraps to -2**255. If y == 0, q and r are set to 0 (OBS: differs from the
+// big.Int)
+
+// QuoRem sets z to the quotient x/y truncated toward zero and r to the
+// remainder x - z*y, and returns the pair (z, r).
+func (z *Int256) QuoRem(x, y, r *Int256) (*Int256, *Int256) {
+	if y.IsZero() {
+		*z, *r = Int256{}, Int256{}
+		return z, r
+	}
+	var ax, ay Int
+	ax.Abs(x.Unsigned())
+	ay.Abs(y.Unsigned())
+	q, rem := DivMod(&ax, &ay)
+	xNeg, yNeg := x.Sign() < 0, y.Sign() < 0
+	if xNeg != yNeg {
+		q.Neg(&q)
+	}
+	if xNeg {
+		rem.Neg(&rem)
+	}
+	*z, *r = Int256(q), Int256(rem)
+	return z, r
+}
+
+// DivModFloor sets z to the quotient x/y rounded toward negative infinity
+// and m to the remainder x - z*y, and returns the pair (z, m).
+func (z *Int256) DivModFloor(x, y, m *Int256) (*Int256, *Int256) {
+	yy := *y
+	z.QuoRem(x, &yy, m)
+	if !m.IsZero() && (m.Sign() < 0) != (yy.Sign() < 0) {
+		z.Sub(z, NewInt256(1))
+		m.Add(m, &yy)
+	}
+	return z, m
+}
+
+// DivModEuclid sets z to the Euclidean quotient of x/y and m to the
+// remainder x - z*y, with 0 <= m < |y|, and returns the pair (z, m).
+func (z *Int256) DivModEuclid(x, y, m *Int256) (*Int256, *Int256) {
+	yy := *y
+	z.QuoRem(x, &yy, m)
+	if m.Sign() < 0 {
+		if yy.Sign() > 0 {
+			z.Sub(z, NewInt256(1))
+			m.Add(m, &yy)
+		} else {
+			z.Add(z, NewInt256(1))
+			m.Sub(m, &yy)
+		}
+	}
+	return z, m
+}
+
+// IsZero returns true if z == 0.
+func (z *Int256) IsZero() bool {
+	return z.Unsigned().IsZero()
+}
+
+// Neg sets z to -x and returns z.
+func (z *Int256) Neg(x *Int256) *Int256 {
+	z.Unsigned().Neg(x.Unsigned())
+	return z
+}
+
+// Abs sets z to |x| and returns z. Abs(-2**255) wraps to -2**255.
+func (z *Int256) Abs(x *Int256) *Int256 {
+	z.Unsigned().Abs(x.Unsigned())
+	return z
+}
+
+// ToBig returns a big.Int version of z.
+func (z *Int256) ToBig() *big.Int {
+	if z.Sign() < 0 {
+		b := new(Int).Neg(z.Unsigned()).ToBig()
+		return b.Neg(b)
+	}
+	return z.Unsigned().ToBig()
+}
+
+// Hex returns the signed hexadecimal encoding of z, such as "-0x1f".
+func (z *Int256) Hex() string {
+	if z.Sign() < 0 {
+		return "-" + new(Int).Neg(z.Unsigned()).Hex()
+	}
+	return z.Unsigned().Hex()
+}
+
+// String returns the signed hexadecimal encoding of z, like Hex.
+func (z *Int256) String() string {
+	return z.Hex()
+}
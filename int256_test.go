@@ -0,0 +1,178 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+var (
+	bigMinInt256 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	bigMaxInt256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+)
+
+// bigWrap256 reduces b into the range of Int256.
+func bigWrap256(b *big.Int) *big.Int {
+	b.Mod(b, bigtt256)
+	if b.Cmp(bigMaxInt256) > 0 {
+		b.Sub(b, bigtt256)
+	}
+	return b
+}
+
+func int256Values() []*big.Int {
+	vals := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(2), big.NewInt(-7),
+		big.NewInt(math.MaxInt64), big.NewInt(math.MinInt64),
+		bigMinInt256, bigMaxInt256,
+		new(big.Int).Add(bigMinInt256, big.NewInt(1)),
+	}
+	for i := 0; i < 40; i++ {
+		b, _, _ := randNums()
+		b.Rsh(b, uint(i*6))
+		vals = append(vals, bigWrap256(b))
+	}
+	return vals
+}
+
+func TestInt256Arith(t *testing.T) {
+	vals := int256Values()
+	for _, bx := range vals {
+		x, overflow := Int256FromBig(bx)
+		if overflow {
+			t.Fatalf("Int256FromBig(%v) overflowed", bx)
+		}
+		if got := x.ToBig(); got.Cmp(bx) != 0 {
+			t.Fatalf("ToBig: got %v, want %v", got, bx)
+		}
+		if got, want := x.String(), fmt.Sprintf("%#x", bx); got != want {
+			t.Fatalf("String: got %v, want %v", got, want)
+		}
+		if got, want := x.Sign(), bx.Sign(); got != want {
+			t.Fatalf("Sign(%v): got %d, want %d", bx, got, want)
+		}
+		if got, want := new(Int256).Neg(x).ToBig(), bigWrap256(new(big.Int).Neg(bx)); got.Cmp(want) != 0 {
+			t.Fatalf("Neg(%v): got %v, want %v", bx, got, want)
+		}
+		if got, want := new(Int256).Abs(x).ToBig(), bigWrap256(new(big.Int).Abs(bx)); got.Cmp(want) != 0 {
+			t.Fatalf("Abs(%v): got %v, want %v", bx, got, want)
+		}
+		for _, by := range vals {
+			y, _ := Int256FromBig(by)
+			msg := fmt.Sprintf("(%v, %v)", bx, by)
+			if got, want := x.Cmp(y), bx.Cmp(by); got != want {
+				t.Fatalf("Cmp%s: got %d, want %d", msg, got, want)
+			}
+			for _, op := range []struct {
+				name   string
+				native func(z, x, y *Int256) *Int256
+				big    func(z, x, y *big.Int) *big.Int
+			}{
+				{"Add", (*Int256).Add, (*big.Int).Add},
+				{"Sub", (*Int256).Sub, (*big.Int).Sub},
+				{"Mul", (*Int256).Mul, (*big.Int).Mul},
+				{"Div", (*Int256).Div, func(z, x, y *big.Int) *big.Int {
+					if y.Sign() == 0 {
+						return z.SetUint64(0)
+					}
+					return z.Quo(x, y)
+				}},
+			} {
+				want := bigWrap256(op.big(new(big.Int), bx, by))
+				if got := op.native(new(Int256), x, y).ToBig(); got.Cmp(want) != 0 {
+					t.Fatalf("%s%s: got %v, want %v", op.name, msg, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestInt256Conversions(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, 42, -42, math.MaxInt64, math.MinInt64} {
+		x := NewInt256(v)
+		if !x.IsInt64() || x.Int64() != v {
+			t.Errorf("NewInt256(%d): got %v", v, x)
+		}
+		if got := x.ToBig(); got.Int64() != v {
+			t.Errorf("NewInt256(%d).ToBig(): got %v", v, got)
+		}
+	}
+	if x := new(Int256).SetUnsigned(new(Int).SetAllOne()); x.Int64() != -1 || !x.IsInt64() {
+		t.Errorf("SetUnsigned(2**256-1): got %v, want -1", x)
+	}
+	big64 := new(Int256).SetUnsigned(new(Int).Lsh(NewInt(1), 63))
+	if big64.IsInt64() {
+		t.Errorf("IsInt64(2**63): got true")
+	}
+	for _, b := range []*big.Int{
+		new(big.Int).Add(bigMaxInt256, big.NewInt(1)),
+		new(big.Int).Sub(bigMinInt256, big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(1), 300),
+	} {
+		x, overflow := Int256FromBig(b)
+		if !overflow {
+			t.Errorf("Int256FromBig(%v): no overflow", b)
+		}
+		if got, want := x.ToBig(), bigWrap256(new(big.Int).Set(b)); got.Cmp(want) != 0 {
+			t.Errorf("Int256FromBig(%v): got %v, want %v", b, got, want)
+		}
+	}
+}
+
+func TestInt256Division(t *testing.T) {
+	vals := append(int256Values(), big.NewInt(7), big.NewInt(-7), big.NewInt(3), big.NewInt(-3))
+	for _, bx := range vals {
+		for _, by := range vals {
+			x, _ := Int256FromBig(bx)
+			y, _ := Int256FromBig(by)
+			msg := fmt.Sprintf("(%v, %v)", bx, by)
+			var tq, tr, fq, fr, eq, er *big.Int
+			if by.Sign() == 0 {
+				tq, tr, fq, fr, eq, er = new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+			} else {
+				tq, tr = new(big.Int).QuoRem(bx, by, new(big.Int))
+				eq, er = new(big.Int).DivMod(bx, by, new(big.Int))
+				fq, fr = new(big.Int).Set(tq), new(big.Int).Set(tr)
+				if tr.Sign() != 0 && tr.Sign() != by.Sign() {
+					fq.Sub(fq, big.NewInt(1))
+					fr.Add(fr, by)
+				}
+			}
+			for _, tc := range []struct {
+				name         string
+				fn           func(z, x, y, r *Int256) (*Int256, *Int256)
+				wantQ, wantR *big.Int
+			}{
+				{"QuoRem", (*Int256).QuoRem, tq, tr},
+				{"DivModFloor", (*Int256).DivModFloor, fq, fr},
+				{"DivModEuclid", (*Int256).DivModEuclid, eq, er},
+			} {
+				q, r := tc.fn(new(Int256), x, y, new(Int256))
+				if q.ToBig().Cmp(bigWrap256(new(big.Int).Set(tc.wantQ))) != 0 || r.ToBig().Cmp(tc.wantR) != 0 {
+					t.Fatalf("%s%s: got (%v, %v), want (%v, %v)", tc.name, msg, q, r, tc.wantQ, tc.wantR)
+				}
+			}
+			// The truncated remainder is that of SMod.
+			_, r := new(Int256).QuoRem(x, y, new(Int256))
+			if m := new(Int).SMod(x.Unsigned(), y.Unsigned()); !m.Eq(r.Unsigned()) {
+				t.Fatalf("SMod%s: got %v, QuoRem remainder %v", msg, m, r)
+			}
+		}
+	}
+	// The outputs may alias the inputs.
+	x, y := NewInt256(-7), NewInt256(2)
+	x.DivModFloor(x, y, y)
+	if x.Int64() != -4 || y.Int64() != 1 {
+		t.Errorf("aliased DivModFloor(-7, 2): got (%v, %v), want (-4, 1)", x, y)
+	}
+	x, y = NewInt256(-7), NewInt256(-2)
+	y.DivModEuclid(x, y, x)
+	if y.Int64() != 4 || x.Int64() != 1 {
+		t.Errorf("aliased DivModEuclid(-7, -2): got (%v, %v), want (4, 1)", y, x)
+	}
+}
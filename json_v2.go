@@ -0,0 +1,34 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build goexperiment.jsonv2
+// +build goexperiment.jsonv2
+
+package uint256
+
+import "encoding/json/jsontext"
+
+// MarshalJSONTo implements the json/v2 MarshalerTo interface, writing z as
+// a 0x-prefixed hex string without intermediate allocations.
+func (z *Int) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var (
+		hex [66]byte
+		buf [68]byte
+	)
+	h := z.hex(&hex)
+	buf[0] = '"'
+	n := copy(buf[1:], h)
+	buf[n+1] = '"'
+	return enc.WriteValue(buf[:n+2])
+}
+
+// UnmarshalJSONFrom implements the json/v2 UnmarshalerFrom interface,
+// accepting the same 0x-prefixed hex strings as UnmarshalJSON.
+func (z *Int) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return z.UnmarshalJSON(val)
+}
@@ -0,0 +1,66 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build goexperiment.jsonv2
+// +build goexperiment.jsonv2
+
+package uint256
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"math/big"
+	"testing"
+)
+
+func TestJSONv2(t *testing.T) {
+	type jsonStruct struct {
+		Foo *Int
+		Bar Int
+	}
+	for _, tc := range unTestCases {
+		bb, _ := new(big.Int).SetString(tc, 0)
+		b, _ := FromBig(bb)
+		in := jsonStruct{Foo: b, Bar: *b}
+		data, err := json.Marshal(&in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"Foo":"` + b.Hex() + `","Bar":"` + b.Hex() + `"}`
+		if string(data) != want {
+			t.Fatalf("got %s, want %s", data, want)
+		}
+		var out jsonStruct
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+		if !out.Foo.Eq(b) || !out.Bar.Eq(b) {
+			t.Fatalf("round trip: got %x %x, want %x", out.Foo, &out.Bar, b)
+		}
+	}
+	var z Int
+	if err := json.Unmarshal([]byte(`123`), &z); err == nil {
+		t.Error("expected error for a JSON number")
+	}
+	if err := json.Unmarshal([]byte(`"0x"`), &z); err == nil {
+		t.Error("expected error for an empty hex number")
+	}
+}
+
+func TestJSONv2Allocs(t *testing.T) {
+	z, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	var buf bytes.Buffer
+	enc := jsontext.NewEncoder(&buf)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		enc.Reset(&buf)
+		if err := z.MarshalJSONTo(enc); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("MarshalJSONTo allocated %v times", allocs)
+	}
+}
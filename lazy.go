@@ -0,0 +1,62 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "math/bits"
+
+// Accumulator sums 512-bit products without reducing them, and reduces the
+// total once at the end. For an inner product sum a_i*b_i mod m this saves
+// a reduction per term compared to MulMod and AddMod. The sum is kept in
+// 576 bits, so at least 2**64 terms can be added. The zero value is an
+// empty sum.
+type Accumulator struct {
+	sum [9]uint64
+}
+
+// AddProduct adds x*y to the sum.
+func (a *Accumulator) AddProduct(x, y *Int) {
+	p := umul(x, y)
+	a.addWide(&p)
+}
+
+// Add adds x to the sum.
+func (a *Accumulator) Add(x *Int) {
+	p := [8]uint64{x[0], x[1], x[2], x[3]}
+	a.addWide(&p)
+}
+
+func (a *Accumulator) addWide(p *[8]uint64) {
+	var carry uint64
+	for i := range p {
+		a.sum[i], carry = bits.Add64(a.sum[i], p[i], carry)
+	}
+	a.sum[8] += carry
+}
+
+// Reduce sets z to the sum modulo m, and returns z. The sum is left
+// unchanged.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (a *Accumulator) Reduce(z, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	if a.sum[8] == 0 {
+		lo := [8]uint64{a.sum[0], a.sum[1], a.sum[2], a.sum[3], a.sum[4], a.sum[5], a.sum[6], a.sum[7]}
+		r := reduce512(&lo, m)
+		return z.Set(&r)
+	}
+	// Reduce the top 512 bits first, then the remainder shifted up by one
+	// word together with the lowest word.
+	hi := [8]uint64{a.sum[1], a.sum[2], a.sum[3], a.sum[4], a.sum[5], a.sum[6], a.sum[7], a.sum[8]}
+	r := reduce512(&hi, m)
+	lo := [8]uint64{a.sum[0], r[0], r[1], r[2], r[3]}
+	r = reduce512(&lo, m)
+	return z.Set(&r)
+}
+
+// Reset empties the sum.
+func (a *Accumulator) Reset() {
+	a.sum = [9]uint64{}
+}
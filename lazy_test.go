@@ -0,0 +1,70 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestAccumulator(t *testing.T) {
+	max := new(Int).SetAllOne()
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		var acc Accumulator
+		want := new(big.Int)
+		for i := 0; i < 40; i++ {
+			bx, x, _ := randHighNums()
+			by, y, _ := randHighNums()
+			if i < 5 {
+				// All-ones products make the sum exceed 512 bits.
+				bx, x = max.ToBig(), max
+				by, y = max.ToBig(), max
+			}
+			acc.AddProduct(x, y)
+			want.Add(want, new(big.Int).Mul(bx, by))
+			if i%3 == 0 {
+				acc.Add(x)
+				want.Add(want, bx)
+			}
+			requireEq(t, new(big.Int).Mod(want, bm), acc.Reduce(new(Int), m), "Accumulator.Reduce")
+		}
+		if got := acc.Reduce(new(Int), new(Int)); !got.IsZero() {
+			t.Errorf("Reduce with zero modulus: got %v, want 0", got)
+		}
+		acc.Reset()
+		if got := acc.Reduce(new(Int), m); !got.IsZero() {
+			t.Errorf("Reduce after Reset: got %v, want 0", got)
+		}
+	}
+}
+
+func BenchmarkAccumulator(b *testing.B) {
+	m := mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	xs := make([]Int, 16)
+	for i := range xs {
+		_, x, _ := randHighNums()
+		xs[i].Mod(x, m)
+	}
+	b.Run("Accumulator", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			var acc Accumulator
+			for j := range xs {
+				acc.AddProduct(&xs[j], &xs[len(xs)-1-j])
+			}
+			acc.Reduce(&z, m)
+		}
+	})
+	b.Run("MulMod", func(b *testing.B) {
+		var z, t Int
+		for i := 0; i < b.N; i++ {
+			z.Clear()
+			for j := range xs {
+				z.AddMod(&z, t.MulMod(&xs[j], &xs[len(xs)-1-j], m), m)
+			}
+		}
+	})
+}
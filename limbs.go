@@ -0,0 +1,85 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "math/bits"
+
+// Conversions to and from alternative limb layouts, as used by GPU and
+// vectorised kernels. All layouts are little-endian: limb 0 is the least
+// significant.
+
+// Uint32Limbs returns z as eight 32-bit limbs.
+func (z *Int) Uint32Limbs() (limbs [8]uint32) {
+	for i, w := range z {
+		limbs[2*i] = uint32(w)
+		limbs[2*i+1] = uint32(w >> 32)
+	}
+	return limbs
+}
+
+// SetUint32Limbs sets z to the value of eight 32-bit limbs and returns z.
+func (z *Int) SetUint32Limbs(limbs *[8]uint32) *Int {
+	for i := range z {
+		z[i] = uint64(limbs[2*i]) | uint64(limbs[2*i+1])<<32
+	}
+	return z
+}
+
+// Uint16Limbs returns z as sixteen 16-bit limbs.
+func (z *Int) Uint16Limbs() (limbs [16]uint16) {
+	for i, w := range z {
+		for j := 0; j < 4; j++ {
+			limbs[4*i+j] = uint16(w >> (16 * uint(j)))
+		}
+	}
+	return limbs
+}
+
+// SetUint16Limbs sets z to the value of sixteen 16-bit limbs and returns z.
+func (z *Int) SetUint16Limbs(limbs *[16]uint16) *Int {
+	for i := range z {
+		z[i] = 0
+		for j := 0; j < 4; j++ {
+			z[i] |= uint64(limbs[4*i+j]) << (16 * uint(j))
+		}
+	}
+	return z
+}
+
+const radix52Mask = 1<<52 - 1
+
+// Radix52 returns z as five 52-bit limbs in 64-bit words, the layout used
+// by IEEE double and IFMA based multipliers.
+func (z *Int) Radix52() [5]uint64 {
+	return [5]uint64{
+		z[0] & radix52Mask,
+		(z[0]>>52 | z[1]<<12) & radix52Mask,
+		(z[1]>>40 | z[2]<<24) & radix52Mask,
+		(z[2]>>28 | z[3]<<36) & radix52Mask,
+		z[3] >> 16,
+	}
+}
+
+// SetRadix52 sets z to sum limbs[i] * 2**(52*i) and returns z. The limbs
+// need not be normalised: each may use all 64 bits, as left by kernels
+// which defer carry propagation. The returned bool reports whether the
+// value overflowed 256 bits, in which case z holds it modulo 2**256.
+func (z *Int) SetRadix52(limbs *[5]uint64) (*Int, bool) {
+	var res [5]uint64 // 320 bits
+	for i, l := range limbs {
+		w, s := 52*i/64, uint(52*i%64)
+		var carry uint64
+		res[w], carry = bits.Add64(res[w], l<<s, 0)
+		for k := w + 1; k < len(res); k++ {
+			var hi uint64
+			if s != 0 && k == w+1 {
+				hi = l >> (64 - s)
+			}
+			res[k], carry = bits.Add64(res[k], hi, carry)
+		}
+	}
+	copy(z[:], res[:4])
+	return z, res[4] != 0
+}
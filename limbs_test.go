@@ -0,0 +1,72 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestLimbs(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		b, f, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		l32 := f.Uint32Limbs()
+		l16 := f.Uint16Limbs()
+		l52 := f.Radix52()
+		want := new(big.Int)
+		for j := len(l32) - 1; j >= 0; j-- {
+			want.Lsh(want, 32).Or(want, big.NewInt(int64(l32[j])))
+		}
+		requireEq(t, want, f, "Uint32Limbs")
+		want.SetUint64(0)
+		for j := len(l16) - 1; j >= 0; j-- {
+			want.Lsh(want, 16).Or(want, big.NewInt(int64(l16[j])))
+		}
+		requireEq(t, want, f, "Uint16Limbs")
+		want.SetUint64(0)
+		for j := len(l52) - 1; j >= 0; j-- {
+			if l52[j] >= 1<<52 {
+				t.Fatalf("limb %d of %x not normalised: %x", j, b, l52[j])
+			}
+			want.Lsh(want, 52).Or(want, new(big.Int).SetUint64(l52[j]))
+		}
+		requireEq(t, want, f, "Radix52")
+
+		var z Int
+		if !z.SetUint32Limbs(&l32).Eq(f) {
+			t.Errorf("SetUint32Limbs(%v) = %x, want %x", l32, &z, f)
+		}
+		if !z.SetUint16Limbs(&l16).Eq(f) {
+			t.Errorf("SetUint16Limbs(%v) = %x, want %x", l16, &z, f)
+		}
+		if _, overflow := z.SetRadix52(&l52); overflow || !z.Eq(f) {
+			t.Errorf("SetRadix52(%v) = %x, %v, want %x", l52, &z, overflow, f)
+		}
+	}
+}
+
+func TestSetRadix52Unnormalised(t *testing.T) {
+	for _, limbs := range [][5]uint64{
+		{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), 0},
+		{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), 1<<48 - 1},
+		{0, 0, 0, 0, 1 << 48},
+		{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)},
+		{1, 1 << 52, 0, 0, 0},
+	} {
+		want := new(big.Int)
+		for j := len(limbs) - 1; j >= 0; j-- {
+			want.Lsh(want, 52).Add(want, new(big.Int).SetUint64(limbs[j]))
+		}
+		var z Int
+		_, overflow := z.SetRadix52(&limbs)
+		if overflow != (want.BitLen() > 256) {
+			t.Errorf("SetRadix52(%x): got overflow %v", limbs, overflow)
+		}
+		requireEq(t, want.Mod(want, bigtt256), &z, "SetRadix52")
+	}
+}
@@ -0,0 +1,90 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "errors"
+
+var (
+	ErrLiteralSyntax = errors.New("invalid integer literal")
+	ErrLiteralRange  = errors.New("integer literal > 256 bits")
+)
+
+// SetFromLiteral sets z to the value of s, written as a Go integer literal:
+// decimal, or with a 0x/0X (hexadecimal), 0b/0B (binary), 0o/0O or a plain
+// leading 0 (octal) prefix. Underscores may separate digits, and follow
+// the prefix, as in "0x_dead_beef" or "1_000_000". This matches
+// big.Int.SetString with base 0, without a sign.
+// On error z is left unmodified.
+func (z *Int) SetFromLiteral(s string) error {
+	if s == "" {
+		return ErrLiteralSyntax
+	}
+	base, digits := uint64(10), s
+	// prefixed reports whether an underscore may follow the current
+	// position: after a base prefix or after a digit.
+	prefixed := false
+	if len(s) > 1 && s[0] == '0' {
+		prefixed = true
+		switch s[1] {
+		case 'x', 'X':
+			base, digits = 16, s[2:]
+		case 'b', 'B':
+			base, digits = 2, s[2:]
+		case 'o', 'O':
+			base, digits = 8, s[2:]
+		default:
+			base, digits = 8, s[1:]
+		}
+	}
+	var res Int
+	sawDigit, afterSep := false, prefixed
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if c == '_' {
+			if !afterSep {
+				return ErrLiteralSyntax
+			}
+			afterSep = false
+			continue
+		}
+		var d uint64
+		switch {
+		case c >= '0' && c <= '9':
+			d = uint64(c - '0')
+		case c >= 'a' && c <= 'z':
+			d = uint64(c-'a') + 10
+		case c >= 'A' && c <= 'Z':
+			d = uint64(c-'A') + 10
+		default:
+			return ErrLiteralSyntax
+		}
+		if d >= base {
+			return ErrLiteralSyntax
+		}
+		if _, overflow := res.MulOverflow(&res, &Int{base}); overflow {
+			return ErrLiteralRange
+		}
+		if _, overflow := res.AddOverflow(&res, &Int{d}); overflow {
+			return ErrLiteralRange
+		}
+		sawDigit, afterSep = true, true
+	}
+	// A prefix without digits, or a trailing underscore, is invalid.
+	if !sawDigit || !afterSep {
+		return ErrLiteralSyntax
+	}
+	z.Set(&res)
+	return nil
+}
+
+// FromLiteral is a convenience-constructor to create an Int from a Go
+// integer literal, as accepted by SetFromLiteral.
+func FromLiteral(s string) (*Int, error) {
+	var z Int
+	if err := z.SetFromLiteral(s); err != nil {
+		return nil, err
+	}
+	return &z, nil
+}
@@ -0,0 +1,59 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSetFromLiteral(t *testing.T) {
+	for _, s := range []string{
+		"", "0", "00", "1", "01", "07", "08", "0_7", "0_", "_0", "1_", "1__0", "1_0",
+		"0x", "0X", "0x0", "0x_1", "0x1_", "0x_", "0xdead_BEEF", "0xg", "0x__1",
+		"0b", "0b101", "0B_1_0", "0b2", "0o", "0o17", "0O_7", "0o8",
+		"123456789", "12a", "1.0", " 1", "+1", "-1", "0x-1",
+		"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+		"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0x1_0000000000000000000000000000000000000000000000000000000000000000",
+		"0x0000000000000000000000000000000000000000000000000000000000000000000001",
+		"0b" + "1111111111111111111111111111111111111111111111111111111111111111" +
+			"1111111111111111111111111111111111111111111111111111111111111111" +
+			"1111111111111111111111111111111111111111111111111111111111111111" +
+			"1111111111111111111111111111111111111111111111111111111111111111",
+	} {
+		want, ok := new(big.Int).SetString(s, 0)
+		if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+			ok = false // signs are not accepted
+		}
+		z := Int{1, 2, 3, 4}
+		err := z.SetFromLiteral(s)
+		switch {
+		case !ok:
+			if err != ErrLiteralSyntax {
+				t.Errorf("%q: got error %v, want ErrLiteralSyntax", s, err)
+			}
+		case want.BitLen() > 256:
+			if err != ErrLiteralRange {
+				t.Errorf("%q: got error %v, want ErrLiteralRange", s, err)
+			}
+		case err != nil:
+			t.Errorf("%q: unexpected error %v", s, err)
+		default:
+			requireEq(t, want, &z, s)
+			continue
+		}
+		if z != (Int{1, 2, 3, 4}) {
+			t.Errorf("%q: receiver modified on error", s)
+		}
+	}
+	if z, err := FromLiteral("0o777"); err != nil || !z.Eq(NewInt(511)) {
+		t.Errorf("FromLiteral: got %v, %v", z, err)
+	}
+	if _, err := FromLiteral("0o778"); err != ErrLiteralSyntax {
+		t.Errorf("FromLiteral: got %v, want ErrLiteralSyntax", err)
+	}
+}
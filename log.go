@@ -0,0 +1,35 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// pow10 holds the powers of ten that fit in an Int, 10**0 to 10**77.
+var pow10 = func() (t [78]Int) {
+	t[0].SetOne()
+	for i := 1; i < len(t); i++ {
+		t[i].Mul(&t[i-1], NewInt(10))
+	}
+	return t
+}()
+
+// Log2 returns the base-2 logarithm of z, rounded down, or -1 if z is 0.
+func (z *Int) Log2() int {
+	return z.BitLen() - 1
+}
+
+// Log10 returns the base-10 logarithm of z, rounded down, or -1 if z is 0.
+// One more than it is the number of decimal digits of a nonzero z.
+func (z *Int) Log10() int {
+	if z.IsZero() {
+		return -1
+	}
+	// 1233/4096 is just below log10(2). For bit lengths up to 256 the
+	// shortfall stays under 0.002, so the estimate is either exact or one
+	// too large.
+	n := z.BitLen() * 1233 >> 12
+	if z.Lt(&pow10[n]) {
+		n--
+	}
+	return n
+}
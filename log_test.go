@@ -0,0 +1,46 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestLog(t *testing.T) {
+	check := func(x *Int) {
+		t.Helper()
+		want2, want10 := -1, -1
+		if !x.IsZero() {
+			want2 = x.ToBig().BitLen() - 1
+			want10 = len(x.ToBig().String()) - 1
+		}
+		if got := x.Log2(); got != want2 {
+			t.Fatalf("Log2(%v): got %d, want %d", x, got, want2)
+		}
+		if got := x.Log10(); got != want10 {
+			t.Fatalf("Log10(%v): got %d, want %d", x, got, want10)
+		}
+	}
+	check(new(Int))
+	check(new(Int).SetAllOne())
+	for i := range pow10 {
+		check(&pow10[i])
+		check(new(Int).SubUint64(&pow10[i], 1))
+		check(new(Int).AddUint64(&pow10[i], 1))
+	}
+	for i := uint(0); i < 256; i++ {
+		p := new(Int).Lsh(NewInt(1), i)
+		check(p)
+		check(new(Int).SubUint64(p, 1))
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		check(x.Rsh(x, uint(i%256)))
+	}
+	if got := pow10[77].ToBig(); got.Cmp(new(big.Int).Exp(big.NewInt(10), big.NewInt(77), nil)) != 0 {
+		t.Fatalf("pow10[77]: got %v", got)
+	}
+}
@@ -0,0 +1,254 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "io"
+
+// MaxMatrixSize is the largest supported Matrix dimension.
+const MaxMatrixSize = 8
+
+// Matrix is a square matrix of size at most MaxMatrixSize over the
+// integers modulo m, stored inline so that matrix operations do not
+// allocate. The zero value is a 0×0 matrix; use NewMatrix or Init to set
+// the size. Operations taking several matrices panic if the sizes differ.
+//
+// Determinant and inversion assume m is prime.
+type Matrix struct {
+	n int
+	a [MaxMatrixSize][MaxMatrixSize]Int
+}
+
+// NewMatrix returns a new n×n zero matrix.
+func NewMatrix(n int) *Matrix {
+	return new(Matrix).Init(n)
+}
+
+// Init sets x to the n×n zero matrix and returns x. It panics if n is
+// negative or greater than MaxMatrixSize.
+func (x *Matrix) Init(n int) *Matrix {
+	if n < 0 || n > MaxMatrixSize {
+		panic("uint256: invalid matrix size")
+	}
+	*x = Matrix{n: n}
+	return x
+}
+
+// SetIdentity sets x to the n×n identity matrix and returns x.
+func (x *Matrix) SetIdentity(n int) *Matrix {
+	x.Init(n)
+	for i := 0; i < n; i++ {
+		x.a[i][i].SetOne()
+	}
+	return x
+}
+
+// Size returns the number of rows (and columns) of x.
+func (x *Matrix) Size() int {
+	return x.n
+}
+
+// At returns a pointer to the element in row i and column j, which may be
+// used to read or set it.
+func (x *Matrix) At(i, j int) *Int {
+	if i >= x.n || j >= x.n {
+		panic("uint256: matrix index out of range")
+	}
+	return &x.a[i][j]
+}
+
+// Equal reports whether x and y have the same size and elements.
+func (x *Matrix) Equal(y *Matrix) bool {
+	return *x == *y
+}
+
+func (x *Matrix) checkSize(y *Matrix) {
+	if x.n != y.n {
+		panic("uint256: matrix size mismatch")
+	}
+}
+
+// reduced returns a copy of x with all elements reduced modulo m.
+func (x *Matrix) reduced(m *Int) Matrix {
+	r := Matrix{n: x.n}
+	for i := 0; i < x.n; i++ {
+		for j := 0; j < x.n; j++ {
+			r.a[i][j].Mod(&x.a[i][j], m)
+		}
+	}
+	return r
+}
+
+// Mul sets z to the product x*y modulo m and returns z.
+// If m == 0, z is set to the zero matrix (OBS: differs from the big.Int)
+func (z *Matrix) Mul(x, y *Matrix, m *Int) *Matrix {
+	x.checkSize(y)
+	res := Matrix{n: x.n}
+	if !m.IsZero() {
+		xr, yr := x.reduced(m), y.reduced(m)
+		for i := 0; i < x.n; i++ {
+			for j := 0; j < x.n; j++ {
+				for k := 0; k < x.n; k++ {
+					res.a[i][j] = mulAddMod(&xr.a[i][k], &yr.a[k][j], &res.a[i][j], m)
+				}
+			}
+		}
+	}
+	*z = res
+	return z
+}
+
+// MulVec sets z to the matrix-vector product x*v modulo m, and returns z.
+// v and z must have length x.Size(); z may alias v.
+// If m == 0, z is set to the zero vector (OBS: differs from the big.Int)
+func (x *Matrix) MulVec(z, v []Int, m *Int) []Int {
+	if len(v) != x.n || len(z) != x.n {
+		panic("uint256: vector size mismatch")
+	}
+	var res [MaxMatrixSize]Int
+	if !m.IsZero() {
+		xr := x.reduced(m)
+		var vr [MaxMatrixSize]Int
+		for i := range v {
+			vr[i].Mod(&v[i], m)
+		}
+		for i := 0; i < x.n; i++ {
+			for k := 0; k < x.n; k++ {
+				res[i] = mulAddMod(&xr.a[i][k], &vr[k], &res[i], m)
+			}
+		}
+	}
+	copy(z, res[:x.n])
+	return z
+}
+
+// Exp sets z to x**e modulo m and returns z.
+// If m == 0, z is set to the zero matrix (OBS: differs from the big.Int)
+func (z *Matrix) Exp(x *Matrix, e, m *Int) *Matrix {
+	if m.IsZero() {
+		return z.Init(x.n)
+	}
+	var res, base Matrix
+	res.SetIdentity(x.n)
+	base = x.reduced(m)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		res.Mul(&res, &res, m)
+		if e.isBitSet(uint(i)) {
+			res.Mul(&res, &base, m)
+		}
+	}
+	// Reduce the identity for m == 1.
+	*z = res.reduced(m)
+	return z
+}
+
+// eliminate performs Gauss-Jordan elimination on x modulo the prime m,
+// applying the same row operations to inv if it is not nil. It returns the
+// determinant of x.
+func (x *Matrix) eliminate(inv *Matrix, m *Int) Int {
+	det := Int{1}
+	det.Mod(&det, m)
+	for c := 0; c < x.n; c++ {
+		p := c
+		for p < x.n && x.a[p][c].IsZero() {
+			p++
+		}
+		if p == x.n {
+			return Int{}
+		}
+		if p != c {
+			x.a[p], x.a[c] = x.a[c], x.a[p]
+			if inv != nil {
+				inv.a[p], inv.a[c] = inv.a[c], inv.a[p]
+			}
+			det.subModReduced(&Int{}, &det, m)
+		}
+		det.MulMod(&det, &x.a[c][c], m)
+		var pivInv Int
+		pivInv.InvModPrime(&x.a[c][c], m)
+		for j := 0; j < x.n; j++ {
+			x.a[c][j].MulMod(&x.a[c][j], &pivInv, m)
+			if inv != nil {
+				inv.a[c][j].MulMod(&inv.a[c][j], &pivInv, m)
+			}
+		}
+		for r := 0; r < x.n; r++ {
+			if r == c || x.a[r][c].IsZero() {
+				continue
+			}
+			var f, t Int
+			f.subModReduced(&Int{}, &x.a[r][c], m)
+			for j := 0; j < x.n; j++ {
+				t = mulAddMod(&f, &x.a[c][j], &x.a[r][j], m)
+				x.a[r][j] = t
+				if inv != nil {
+					t = mulAddMod(&f, &inv.a[c][j], &inv.a[r][j], m)
+					inv.a[r][j] = t
+				}
+			}
+		}
+	}
+	return det
+}
+
+// Det sets z to the determinant of x modulo the prime m, and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (x *Matrix) Det(z, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	xr := x.reduced(m)
+	det := xr.eliminate(nil, m)
+	return z.Set(&det)
+}
+
+// Inverse sets z to the inverse of x modulo the prime m, and returns z and
+// true. If x is singular, or m == 0, z is left unmodified and Inverse
+// returns false.
+func (z *Matrix) Inverse(x *Matrix, m *Int) (*Matrix, bool) {
+	if m.IsZero() {
+		return z, false
+	}
+	xr := x.reduced(m)
+	var inv Matrix
+	inv.SetIdentity(x.n)
+	inv = inv.reduced(m)
+	if det := xr.eliminate(&inv, m); det.IsZero() {
+		return z, false
+	}
+	*z = inv
+	return z, true
+}
+
+// VerifyProduct reports whether a*b == c modulo m, using Freivalds'
+// algorithm: it compares a*(b*r) with c*r for rounds random vectors r
+// read from rand, costing O(n²) per round instead of a matrix product.
+// A wrong product is accepted with probability at most (1/q)**rounds,
+// where q is the smallest prime factor of m.
+// If m == 0, all products are zero and VerifyProduct reports true.
+func VerifyProduct(a, b, c *Matrix, m *Int, rounds int, rand io.Reader) (bool, error) {
+	a.checkSize(b)
+	a.checkSize(c)
+	if m.IsZero() {
+		return true, nil
+	}
+	for i := 0; i < rounds; i++ {
+		var r, br, abr, cr [MaxMatrixSize]Int
+		n := a.n
+		for j := 0; j < n; j++ {
+			v, err := RandMod(rand, m)
+			if err != nil {
+				return false, err
+			}
+			r[j] = *v
+		}
+		b.MulVec(br[:n], r[:n], m)
+		a.MulVec(abr[:n], br[:n], m)
+		c.MulVec(cr[:n], r[:n], m)
+		if abr != cr {
+			return false, nil
+		}
+	}
+	return true, nil
+}
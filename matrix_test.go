@@ -0,0 +1,146 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func randMatrix(t *testing.T, n int) *Matrix {
+	x := NewMatrix(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			_, f, err := randNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			x.At(i, j).Set(f)
+		}
+	}
+	return x
+}
+
+func TestMatrixMul(t *testing.T) {
+	bm, m, _ := randNums()
+	for m.IsZero() {
+		bm, m, _ = randNums()
+	}
+	for n := 0; n <= MaxMatrixSize; n++ {
+		x, y := randMatrix(t, n), randMatrix(t, n)
+		z := new(Matrix).Mul(x, y, m)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				want := new(big.Int)
+				for k := 0; k < n; k++ {
+					want.Add(want, new(big.Int).Mul(x.At(i, k).ToBig(), y.At(k, j).ToBig()))
+				}
+				requireEq(t, want.Mod(want, bm), z.At(i, j), "Matrix.Mul")
+			}
+		}
+		// Aliasing.
+		if got := new(Matrix).Mul(x, x, m); !x.Mul(x, x, m).Equal(got) {
+			t.Errorf("n=%d: aliased Mul differs", n)
+		}
+		v := make([]Int, n)
+		for i := range v {
+			v[i].SetUint64(uint64(i) + 1)
+		}
+		got := x.MulVec(make([]Int, n), v, m)
+		for i := range got {
+			var want Int
+			for k := range v {
+				want.AddMod(&want, new(Int).MulMod(x.At(i, k), &v[k], m), m)
+			}
+			if !got[i].Eq(&want) {
+				t.Errorf("n=%d: MulVec[%d] = %v, want %v", n, i, &got[i], &want)
+			}
+		}
+	}
+	if z := new(Matrix).Mul(randMatrix(t, 2), randMatrix(t, 2), new(Int)); !z.Equal(NewMatrix(2)) {
+		t.Errorf("expected zero matrix for zero modulus")
+	}
+}
+
+func TestMatrixExp(t *testing.T) {
+	p := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	// The Fibonacci recurrence: [[1 1] [1 0]]**n = [[F(n+1) F(n)] [F(n) F(n-1)]].
+	fib := NewMatrix(2)
+	fib.At(0, 0).SetOne()
+	fib.At(0, 1).SetOne()
+	fib.At(1, 0).SetOne()
+	for _, e := range []*Int{NewInt(0), NewInt(1), NewInt(10), NewInt(1000), new(Int).SetAllOne()} {
+		z := new(Matrix).Exp(fib, e, p)
+		want := new(Int).FibonacciMod(e, p)
+		if !z.At(0, 1).Eq(want) {
+			t.Errorf("fib**%v: got %v, want %v", e, z.At(0, 1), want)
+		}
+	}
+	if z := new(Matrix).Exp(fib, NewInt(0), NewInt(1)); !z.Equal(NewMatrix(2)) {
+		t.Errorf("expected zero matrix modulo 1")
+	}
+}
+
+func TestMatrixInverse(t *testing.T) {
+	p := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	for _, m := range []*Int{NewInt(7), p} {
+		for n := 1; n <= MaxMatrixSize; n++ {
+			x, y := randMatrix(t, n), randMatrix(t, n)
+			var dx, dy, dxy, want Int
+			x.Det(&dx, m)
+			y.Det(&dy, m)
+			new(Matrix).Mul(x, y, m).Det(&dxy, m)
+			if !dxy.Eq(want.MulMod(&dx, &dy, m)) {
+				t.Errorf("m=%v n=%d: det(xy) = %v, want %v", m, n, &dxy, &want)
+			}
+			inv, ok := new(Matrix).Inverse(x, m)
+			if ok != !dx.IsZero() {
+				t.Fatalf("m=%v n=%d: Inverse ok = %v with det %v", m, n, ok, &dx)
+			}
+			if ok {
+				id := new(Matrix).SetIdentity(n)
+				if got := new(Matrix).Mul(x, inv, m); !got.Equal(id) {
+					t.Errorf("m=%v n=%d: x * x**-1 is not the identity", m, n)
+				}
+			}
+			if n > 1 {
+				// Equal rows make the matrix singular.
+				for j := 0; j < n; j++ {
+					*x.At(1, j) = *x.At(0, j)
+				}
+				if x.Det(&dx, m); !dx.IsZero() {
+					t.Errorf("m=%v n=%d: singular matrix has det %v", m, n, &dx)
+				}
+				orig := *inv
+				if _, ok := inv.Inverse(x, m); ok || !inv.Equal(&orig) {
+					t.Errorf("m=%v n=%d: singular matrix inverted", m, n)
+				}
+			}
+		}
+	}
+	// A row swap flips the sign of the determinant.
+	x := NewMatrix(2)
+	x.At(0, 1).SetOne()
+	x.At(1, 0).SetOne()
+	if d := x.Det(new(Int), NewInt(7)); !d.Eq(NewInt(6)) {
+		t.Errorf("det = %v, want 6", d)
+	}
+}
+
+func TestVerifyProduct(t *testing.T) {
+	p := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	for n := 1; n <= MaxMatrixSize; n++ {
+		a, b := randMatrix(t, n), randMatrix(t, n)
+		c := new(Matrix).Mul(a, b, p)
+		if ok, err := VerifyProduct(a, b, c, p, 2, rand.Reader); err != nil || !ok {
+			t.Errorf("n=%d: correct product rejected: %v", n, err)
+		}
+		c.At(n-1, 0).AddMod(c.At(n-1, 0), NewInt(1), p)
+		if ok, err := VerifyProduct(a, b, c, p, 2, rand.Reader); err != nil || ok {
+			t.Errorf("n=%d: wrong product accepted: %v", n, err)
+		}
+	}
+}
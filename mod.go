@@ -0,0 +1,623 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// ErrZeroModulus is returned by constructors and samplers that need a
+// non-zero modulus, when given m == 0.
+var ErrZeroModulus = errors.New("modulus is zero")
+
+// reciprocal computes the Barrett reciprocal floor((2**512-1) / m) of a
+// modulus with m[3] != 0, which fits in 5 words.
+func reciprocal(m *Int) (mu [5]uint64) {
+	u := [8]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	var quot [8]uint64
+	udivrem(quot[:], u[:], m)
+	copy(mu[:], quot[:5])
+	return mu
+}
+
+// Reciprocal returns the Barrett reciprocal floor((2**512-1) / m) of m,
+// for callers that manage their own precomputation and use
+// MulModWithReciprocal. For m < 2**192, where the reciprocal does not fit
+// and would not help, it returns zero.
+func Reciprocal(m *Int) (mu [5]uint64) {
+	if instrumented {
+		defer region("Reciprocal")()
+	}
+	if m[3] == 0 {
+		return mu
+	}
+	return reciprocal(m)
+}
+
+// MulModWithReciprocal calculates the modulo-m multiplication of x and y
+// using the precomputed mu = Reciprocal(m), and returns z. The result is
+// undefined if mu was not computed for m.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) MulModWithReciprocal(x, y, m *Int, mu *[5]uint64) *Int {
+	if m[3] == 0 {
+		return z.MulMod(x, y, m)
+	}
+	p := umul(x, y)
+	r := reduceBarrett(&p, m, mu)
+	return z.Set(&r)
+}
+
+// Modulus is a fixed modulus with its precomputed Barrett reciprocal, for
+// performing many modular operations with the same modulus. A Modulus is
+// immutable and safe for concurrent use.
+//
+// Moduli below 2**192 do not benefit from the reciprocal, and use plain
+// division. Moduli with a dedicated reduction, such as the secp256k1 and
+// P-256 field primes or those added with RegisterReducer, use it instead.
+type Modulus struct {
+	m       Int
+	mu      [5]uint64
+	barrett bool
+	special ReduceFunc   // dedicated reduction, if any
+	mont    *MontContext // for other odd moduli, used by ExpMod
+	inv     *AddChain    // for m-2, set for moduli pinned by RegisterFixedModulus
+}
+
+// NewModulus returns a Modulus for m.
+func NewModulus(m *Int) *Modulus {
+	return new(Modulus).init(m)
+}
+
+// NewModulusChecked returns a Modulus for m, or ErrZeroModulus if m == 0.
+// A Modulus for 0 is valid, but reduces everything to 0 (OBS: differs from
+// the big.Int), which is seldom what a caller setting one up intends.
+func NewModulusChecked(m *Int) (*Modulus, error) {
+	if m.IsZero() {
+		return nil, ErrZeroModulus
+	}
+	return NewModulus(m), nil
+}
+
+func (mod *Modulus) init(m *Int) *Modulus {
+	if instrumented {
+		defer region("NewModulus")()
+	}
+	*mod = Modulus{m: *m}
+	if mod.special = specialReducer(m); mod.special != nil {
+		return mod
+	}
+	if m[3] != 0 {
+		mod.mu = reciprocal(m)
+		mod.barrett = true
+	}
+	if m[0]&1 == 1 {
+		mod.mont = new(MontContext).init(m)
+	}
+	return mod
+}
+
+// Value returns the modulus as an Int.
+func (mod *Modulus) Value() *Int {
+	return mod.m.Clone()
+}
+
+// reduce returns x mod m for a 512-bit x.
+//
+// It follows the Barrett reduction of the Handbook of Applied Cryptography,
+// algorithm 14.42, with base 2**64 and k = 4: the quotient estimate
+// q = ((x >> 192) * mu) >> 320 is at most 2 less than the true quotient,
+// and the remainder is computed modulo 2**320 and corrected by repeated
+// subtraction.
+func (mod *Modulus) reduce(x *[8]uint64) Int {
+	if mod.special != nil {
+		return mod.special(*x)
+	}
+	if x[4]|x[5]|x[6]|x[7] == 0 {
+		// A product that fits in 256 bits skips the 512-bit reduction: it is
+		// returned as is when already below m, and reduced with Mod otherwise.
+		r := Int{x[0], x[1], x[2], x[3]}
+		if r.Lt(&mod.m) {
+			return r
+		}
+		return *r.Mod(&r, &mod.m)
+	}
+	if !mod.barrett {
+		return reduce512(x, &mod.m)
+	}
+	return reduceBarrett(x, &mod.m, &mod.mu)
+}
+
+// reduceBarrett returns x mod m for m[3] != 0, given mu = reciprocal(m).
+func reduceBarrett(x *[8]uint64, m *Int, mu *[5]uint64) Int {
+	// q3 = ((x >> 192) * mu) >> 320; only the top 5 of the 10 product
+	// words are needed, but the lower ones feed the carries.
+	var q2 [10]uint64
+	for i := 0; i < 5; i++ {
+		var carry uint64
+		for j := 0; j < 5; j++ {
+			hi, lo := bits.Mul64(x[3+i], mu[j])
+			var c uint64
+			lo, c = bits.Add64(lo, q2[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			q2[i+j] = lo
+			carry = hi
+		}
+		q2[i+5] = carry
+	}
+	q3 := q2[5:]
+
+	// r2 = (q3 * m) mod 2**320
+	var r2 [5]uint64
+	for i := 0; i < 5; i++ {
+		var carry uint64
+		for j := 0; j < 4 && i+j < 5; j++ {
+			hi, lo := bits.Mul64(q3[i], m[j])
+			var c uint64
+			lo, c = bits.Add64(lo, r2[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r2[i+j] = lo
+			carry = hi
+		}
+		if i+4 < 5 {
+			r2[i+4] += carry
+		}
+	}
+
+	// r = (x mod 2**320) - r2, modulo 2**320
+	var r [5]uint64
+	var borrow uint64
+	for i := range r {
+		r[i], borrow = bits.Sub64(x[i], r2[i], borrow)
+	}
+	// while r >= m: r -= m
+	for r[4] != 0 || !(&Int{r[0], r[1], r[2], r[3]}).Lt(m) {
+		borrow = 0
+		for i := 0; i < 4; i++ {
+			r[i], borrow = bits.Sub64(r[i], m[i], borrow)
+		}
+		r[4] -= borrow
+	}
+	return Int{r[0], r[1], r[2], r[3]}
+}
+
+// Reduce sets z to x mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) Reduce(z, x *Int) *Int {
+	return z.Mod(x, &mod.m)
+}
+
+// Reduce512 sets z to the 512-bit number hi*2**256 + lo reduced mod m, and
+// returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) Reduce512(z, hi, lo *Int) *Int {
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	x := [8]uint64{lo[0], lo[1], lo[2], lo[3], hi[0], hi[1], hi[2], hi[3]}
+	r := mod.reduce(&x)
+	return z.Set(&r)
+}
+
+// MulMod sets z to x*y mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) MulMod(z, x, y *Int) *Int {
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	p := umul(x, y)
+	r := mod.reduce(&p)
+	return z.Set(&r)
+}
+
+// SqrMod sets z to x*x mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) SqrMod(z, x *Int) *Int {
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	p := usqr(x)
+	r := mod.reduce(&p)
+	return z.Set(&r)
+}
+
+// SetBytesMod sets z to the big-endian unsigned integer b reduced mod m,
+// and returns z. See Int.SetBytesMod.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) SetBytesMod(z *Int, b []byte) *Int {
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	x := wideFromBytes(b)
+	r := mod.reduce(&x)
+	return z.Set(&r)
+}
+
+// wideFromBytes returns the last 64 bytes of the big-endian b as a 512-bit
+// number.
+func wideFromBytes(b []byte) [8]uint64 {
+	if len(b) > 64 {
+		b = b[len(b)-64:]
+	}
+	var lo, hi Int
+	if n := len(b) - 32; n > 0 {
+		hi.SetBytes(b[:n])
+		b = b[n:]
+	}
+	lo.SetBytes(b)
+	return [8]uint64{lo[0], lo[1], lo[2], lo[3], hi[0], hi[1], hi[2], hi[3]}
+}
+
+// AddMod sets z to x+y mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) AddMod(z, x, y *Int) *Int {
+	return z.AddMod(x, y, &mod.m)
+}
+
+// ExpMod sets z to base**exp mod m and returns z, using 4-bit sliding
+// windows. For odd moduli without a dedicated reduction the
+// multiplications are done in Montgomery form.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) ExpMod(z, base, exp *Int) *Int {
+	if instrumented {
+		defer region("ExpMod")()
+	}
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	var b Int
+	mod.Reduce(&b, base)
+	d := mod.expDomain()
+	d.enter(&b, &b)
+	slidingWindowExp(z, &b, &d.one, exp, d.mul, d.sqr)
+	d.leave(z)
+	return z
+}
+
+// expDomain is the representation in which exponentiations modulo a
+// Modulus are carried out: Montgomery form for odd moduli without a
+// dedicated reduction, and plain residues otherwise.
+type expDomain struct {
+	one  Int // the identity, in the domain
+	mul  func(z, x, y *Int)
+	sqr  func(z, x *Int)
+	mont *MontContext
+}
+
+func (mod *Modulus) expDomain() (d expDomain) {
+	d.one.Mod(&Int{1}, &mod.m)
+	if c := mod.mont; c != nil {
+		c.montMul(&d.one, &d.one, &c.r2)
+		d.mul, d.sqr, d.mont = c.montMul, c.montSqr, c
+		return d
+	}
+	d.mul = func(z, x, y *Int) {
+		mod.MulMod(z, x, y)
+	}
+	d.sqr = func(z, x *Int) {
+		mod.SqrMod(z, x)
+	}
+	return d
+}
+
+// enter sets z to the domain representation of x, which must be reduced.
+func (d *expDomain) enter(z, x *Int) {
+	if d.mont != nil {
+		d.mont.montMul(z, x, &d.mont.r2)
+	} else {
+		z.Set(x)
+	}
+}
+
+// leave converts z from the domain representation back to a residue.
+func (d *expDomain) leave(z *Int) {
+	if d.mont != nil {
+		d.mont.montMul(z, z, &Int{1})
+	}
+}
+
+// slidingWindowExp sets z to base**exp with 4-bit sliding windows, where
+// one is the identity and mul and sqr the multiplication and squaring, and
+// returns z.
+func slidingWindowExp(z, base, one, exp *Int, mul func(z, x, y *Int), sqr func(z, x *Int)) *Int {
+	// table[i] = base**(2*i+1)
+	var table [8]Int
+	table[0] = *base
+	var b2 Int
+	sqr(&b2, base)
+	for i := 1; i < len(table); i++ {
+		mul(&table[i], &table[i-1], &b2)
+	}
+
+	res := *one
+	for i := exp.BitLen() - 1; i >= 0; {
+		if !exp.isBitSet(uint(i)) {
+			sqr(&res, &res)
+			i--
+			continue
+		}
+		// Find the longest window exp[i..l] of at most 4 bits ending in a
+		// set bit.
+		l := i - 3
+		if l < 0 {
+			l = 0
+		}
+		for !exp.isBitSet(uint(l)) {
+			l++
+		}
+		var w uint
+		for j := i; j >= l; j-- {
+			sqr(&res, &res)
+			w <<= 1
+			if exp.isBitSet(uint(j)) {
+				w |= 1
+			}
+		}
+		mul(&res, &res, &table[w>>1])
+		i = l - 1
+	}
+	return z.Set(&res)
+}
+
+// MultiExpMod sets z to the product of bases[i]**exps[i] mod m and returns
+// z. It uses Straus' method: the exponents are scanned together in 4-bit
+// windows, so that all the terms share one sequence of squarings. It panics
+// if the slices differ in length.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) MultiExpMod(z *Int, bases, exps []Int) *Int {
+	if len(bases) != len(exps) {
+		panic("uint256: mismatched multi-exponentiation lengths")
+	}
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	d := mod.expDomain()
+	// tables[i][j] = bases[i]**j
+	tables := make([][16]Int, len(bases))
+	bitLen := 0
+	for i := range bases {
+		var b Int
+		mod.Reduce(&b, &bases[i])
+		d.enter(&b, &b)
+		t := &tables[i]
+		t[0] = d.one
+		for j := 1; j < len(t); j++ {
+			d.mul(&t[j], &t[j-1], &b)
+		}
+		if l := exps[i].BitLen(); l > bitLen {
+			bitLen = l
+		}
+	}
+
+	res := d.one
+	for w := (bitLen+3)/4 - 1; w >= 0; w-- {
+		if w != (bitLen+3)/4-1 {
+			d.sqr(&res, &res)
+			d.sqr(&res, &res)
+			d.sqr(&res, &res)
+			d.sqr(&res, &res)
+		}
+		for i := range exps {
+			if digit := (exps[i][w/16] >> uint(w%16*4)) & 0xf; digit != 0 {
+				d.mul(&res, &res, &tables[i][digit])
+			}
+		}
+	}
+	d.leave(&res)
+	return z.Set(&res)
+}
+
+// ExpMod sets z to base**exp mod m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) ExpMod(base, exp, m *Int) *Int {
+	if mod := fixedModulus(m); mod != nil {
+		return mod.ExpMod(z, base, exp)
+	}
+	var mod Modulus
+	return mod.init(m).ExpMod(z, base, exp)
+}
+
+// InvModPrime sets z to x**(p-2) mod p, the inverse of x modulo the prime
+// p, and returns z. If x is 0 mod p, z is set to 0. Moduli pinned with
+// RegisterFixedModulus carry a precomputed addition chain for p-2, which
+// takes fewer multiplications than a generic exponentiation. The exponent
+// is public, so for odd moduli using Montgomery multiplication or a
+// branch-free dedicated reduction, the running time does not depend on x
+// as long as x < p; reducing a larger x takes a variable-time division.
+// If p < 2, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) InvModPrime(z, x *Int) *Int {
+	if mod.m.LtUint64(2) {
+		return z.Clear()
+	}
+	var b Int
+	mod.Reduce(&b, x)
+	if mod.m.Eq(&Int{2}) {
+		// The exponent p-2 is 0, but 0 has no inverse: x is its own inverse
+		// modulo 2, or 0.
+		return z.Set(&b)
+	}
+	if mod.inv == nil {
+		var e Int
+		e.SubUint64(&mod.m, 2)
+		return mod.ExpMod(z, &b, &e)
+	}
+	d := mod.expDomain()
+	d.enter(&b, &b)
+	mod.inv.apply(z, &b, &d.one, d.mul)
+	d.leave(z)
+	return z
+}
+
+// ExpModUint64 sets z to base**e mod m and returns z, by binary
+// exponentiation over the bits of e.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) ExpModUint64(z, base *Int, e uint64) *Int {
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	var b, res Int
+	mod.Reduce(&b, base)
+	res.Mod(&Int{1}, &mod.m)
+	for i := bits.Len64(e) - 1; i >= 0; i-- {
+		mod.SqrMod(&res, &res)
+		if e>>uint(i)&1 != 0 {
+			mod.MulMod(&res, &res, &b)
+		}
+	}
+	return z.Set(&res)
+}
+
+// ExpModUint64 sets z to base**e mod m and returns z. For the small
+// exponents it is meant for, no reduction constants are precomputed: each
+// step reduces with a division, unless m is pinned with
+// RegisterFixedModulus.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) ExpModUint64(base *Int, e uint64, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	if mod := fixedModulus(m); mod != nil {
+		return mod.ExpModUint64(z, base, e)
+	}
+	var b, res Int
+	b.Mod(base, m)
+	res.Mod(&Int{1}, m)
+	for i := bits.Len64(e) - 1; i >= 0; i-- {
+		res.SqrMod(&res, m)
+		if e>>uint(i)&1 != 0 {
+			res.MulMod(&res, &b, m)
+		}
+	}
+	return z.Set(&res)
+}
+
+// ExpModCT sets z to base**exp mod m and returns z. Unlike ExpMod it uses
+// fixed 4-bit windows over all 256 bits of exp and reads the window table
+// without secret-dependent memory accesses, so that for odd moduli the
+// running time does not depend on the value of exp. For even moduli
+// without a dedicated reduction the reduction is not constant-time.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) ExpModCT(z, base, exp *Int) *Int {
+	if mod.m.IsZero() {
+		return z.Clear()
+	}
+	var b Int
+	mod.Reduce(&b, base)
+	d := mod.expDomain()
+	d.enter(&b, &b)
+	fixedWindowExp(z, &b, &d.one, exp, d.mul, d.sqr)
+	d.leave(z)
+	return z
+}
+
+// fixedWindowExp sets z to base**exp with fixed 4-bit windows, where one is
+// the identity and mul and sqr the multiplication and squaring, and returns
+// z. The sequence of operations and memory accesses is independent of exp.
+func fixedWindowExp(z, base, one, exp *Int, mul func(z, x, y *Int), sqr func(z, x *Int)) *Int {
+	// table[i] = base**i
+	var table [16]Int
+	table[0] = *one
+	for i := 1; i < len(table); i++ {
+		mul(&table[i], &table[i-1], base)
+	}
+
+	var res, t Int
+	res = *one
+	for i := 252; i >= 0; i -= 4 {
+		sqr(&res, &res)
+		sqr(&res, &res)
+		sqr(&res, &res)
+		sqr(&res, &res)
+		w := (exp[i/64] >> uint(i%64)) & 0xf
+		// Scan the whole table, keeping the entry at w.
+		t = Int{}
+		for j := range table {
+			mask := -(((uint64(j) ^ w) - 1) >> 63)
+			t[0] |= table[j][0] & mask
+			t[1] |= table[j][1] & mask
+			t[2] |= table[j][2] & mask
+			t[3] |= table[j][3] & mask
+		}
+		mul(&res, &res, &t)
+	}
+	return z.Set(&res)
+}
+
+// ExpModCT sets z to base**exp mod m and returns z, in time independent of
+// the value of exp for odd m. See Modulus.ExpModCT.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) ExpModCT(base, exp, m *Int) *Int {
+	if mod := fixedModulus(m); mod != nil {
+		return mod.ExpModCT(z, base, exp)
+	}
+	var mod Modulus
+	return mod.init(m).ExpModCT(z, base, exp)
+}
+
+// MultiExpMod sets z to the product of bases[i]**exps[i] mod m and returns
+// z. See Modulus.MultiExpMod.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) MultiExpMod(bases, exps []Int, m *Int) *Int {
+	if mod := fixedModulus(m); mod != nil {
+		return mod.MultiExpMod(z, bases, exps)
+	}
+	var mod Modulus
+	return mod.init(m).MultiExpMod(z, bases, exps)
+}
+
+// SetBytesMod sets z to the big-endian unsigned integer b reduced mod m,
+// and returns z. If b is longer than 64 bytes, only the last 64 are used.
+// This is the final step of hash_to_field in RFC 9380: reducing a 48 or
+// 64 byte hash output, for a modulus of up to 256 bits, gives an element
+// of [0, m) with negligible bias.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) SetBytesMod(b []byte, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	if mod := fixedModulus(m); mod != nil {
+		return mod.SetBytesMod(z, b)
+	}
+	x := wideFromBytes(b)
+	r := reduce512(&x, m)
+	return z.Set(&r)
+}
+
+// RandMod returns a uniformly random element of [0, m), reading 64 bytes
+// from r, such as crypto/rand.Reader, and reducing them mod m. The bias of
+// the reduction is below 2**-256. It returns ErrZeroModulus if m == 0, and
+// any error from reading r.
+func RandMod(r io.Reader, m *Int) (*Int, error) {
+	if m.IsZero() {
+		return nil, ErrZeroModulus
+	}
+	var b [64]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return nil, err
+	}
+	return new(Int).SetBytesMod(b[:], m), nil
+}
+
+// Reduce512 sets z to the 512-bit number hi*2**256 + lo reduced mod m, and
+// returns z. It is meant for callers that produce wide intermediates
+// themselves; for many reductions modulo the same m, Modulus.Reduce512
+// avoids a division per call.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) Reduce512(hi, lo, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	if mod := fixedModulus(m); mod != nil {
+		return mod.Reduce512(z, hi, lo)
+	}
+	x := [8]uint64{lo[0], lo[1], lo[2], lo[3], hi[0], hi[1], hi[2], hi[3]}
+	r := reduce512(&x, m)
+	return z.Set(&r)
+}
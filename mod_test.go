@@ -0,0 +1,366 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func testModuli(t *testing.T) []*big.Int {
+	mods := []*big.Int{
+		big.NewInt(1),
+		big.NewInt(3),
+		new(big.Int).Lsh(big.NewInt(1), 192),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 192), big.NewInt(1)),
+		new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 192), big.NewInt(1)),
+		new(big.Int).Sub(bigtt256, big.NewInt(1)),
+		new(big.Int).Sub(bigtt256, big.NewInt(0x1000003d1)),
+	}
+	for i := 0; i < 20; i++ {
+		b, _, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Sign() != 0 {
+			mods = append(mods, b)
+		}
+		b, _, err = randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Sign() != 0 {
+			mods = append(mods, b)
+		}
+	}
+	return mods
+}
+
+func TestModulus(t *testing.T) {
+	max := new(big.Int).Sub(bigtt256, big.NewInt(1))
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		mod := NewModulus(m)
+		if !mod.Value().Eq(m) {
+			t.Fatalf("Value: got %v, want %v", mod.Value(), m)
+		}
+		for i := 0; i < 50; i++ {
+			bx, x, _ := randNums()
+			by, y, _ := randHighNums()
+			if i == 0 {
+				bx, x = max, new(Int).SetAllOne()
+				by, y = max, new(Int).SetAllOne()
+			}
+			requireEq(t, new(big.Int).Mod(bx, bm), mod.Reduce(new(Int), x), "Modulus.Reduce")
+			requireEq(t, new(big.Int).Mod(new(big.Int).Mul(bx, by), bm), mod.MulMod(new(Int), x, y), "Modulus.MulMod")
+			requireEq(t, new(big.Int).Mod(new(big.Int).Add(bx, by), bm), mod.AddMod(new(Int), x, y), "Modulus.AddMod")
+			mu := Reciprocal(m)
+			requireEq(t, new(big.Int).Mod(new(big.Int).Mul(bx, by), bm), new(Int).MulModWithReciprocal(x, y, m, &mu), "MulModWithReciprocal")
+			if i < 5 {
+				requireEq(t, new(big.Int).Exp(bx, by, bm), mod.ExpMod(new(Int), x, y), "Modulus.ExpMod")
+				requireEq(t, new(big.Int).Exp(bx, by, bm), new(Int).ExpMod(x, y, m), "ExpMod")
+				e := new(big.Int).SetUint64(uint64(i * 37))
+				requireEq(t, new(big.Int).Exp(bx, e, bm), new(Int).ExpMod(x, &Int{uint64(i * 37)}, m), "ExpMod small")
+				requireEq(t, new(big.Int).Exp(bx, by, bm), mod.ExpModCT(new(Int), x, y), "Modulus.ExpModCT")
+				requireEq(t, new(big.Int).Exp(bx, e, bm), new(Int).ExpModCT(x, &Int{uint64(i * 37)}, m), "ExpModCT small")
+			}
+		}
+	}
+	mod := NewModulus(new(Int))
+	x := NewInt(5)
+	for _, got := range []*Int{mod.Reduce(new(Int), x), mod.MulMod(new(Int), x, x), mod.AddMod(new(Int), x, x), mod.ExpMod(new(Int), x, x), new(Int).ExpMod(x, x, new(Int)), new(Int).ExpModCT(x, x, new(Int))} {
+		if !got.IsZero() {
+			t.Errorf("expected 0 for zero modulus, got %v", got)
+		}
+	}
+}
+
+func BenchmarkModulusMulMod(b *testing.B) {
+	m, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	y, _ := FromHex("0x7c6d1b2a3e4f5061728394a5b6c7d8e9fa0b1c2d3e4f5061728394a5b6c7d8e9")
+	mod := NewModulus(m)
+	b.Run("Modulus", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			mod.MulMod(&z, x, y)
+		}
+	})
+	b.Run("Int", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.MulMod(x, y, m)
+		}
+	})
+}
+
+func TestModulusSmallProducts(t *testing.T) {
+	// Products below 2**256 take a shortcut past the Barrett reduction,
+	// both below and above the modulus.
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		mod := NewModulus(m)
+		for _, xy := range [][2]*Int{
+			{NewInt(3), NewInt(5)},
+			{new(Int).Rsh(m, 2), NewInt(3)},
+			{new(Int).Rsh(m, 1), NewInt(2)},
+			{new(Int).Rsh(m, 64), NewInt(0xffffffff)},
+			{&Int{0, 0, 1}, &Int{0, 0x8000000000000000}},
+		} {
+			x, y := xy[0], xy[1]
+			want := new(big.Int).Mul(x.ToBig(), y.ToBig())
+			requireEq(t, want.Mod(want, bm), mod.MulMod(new(Int), x, y), fmt.Sprintf("Modulus.MulMod(%x, %x, %x)", x, y, m))
+		}
+	}
+}
+
+func BenchmarkModulusMulModSmall(b *testing.B) {
+	mod := BN254P()
+	x, y := NewInt(0x12cbafcee8f60f9f), NewInt(0x7c6d1b2a3e4f5061)
+	b.Run("Modulus", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			mod.MulMod(&z, x, y)
+		}
+	})
+	b.Run("Barrett", func(b *testing.B) {
+		p := umul(x, y)
+		for i := 0; i < b.N; i++ {
+			reduceBarrett(&p, &mod.m, &mod.mu)
+		}
+	})
+}
+
+func BenchmarkExpMod(b *testing.B) {
+	m, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	e := new(Int).SubUint64(m, 2)
+	b.Run("uint256", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ExpMod(x, e, m)
+		}
+	})
+	b.Run("uint256/CT", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ExpModCT(x, e, m)
+		}
+	})
+	b.Run("big", func(b *testing.B) {
+		bm, bx, be := m.ToBig(), x.ToBig(), e.ToBig()
+		z := new(big.Int)
+		for i := 0; i < b.N; i++ {
+			z.Exp(bx, be, bm)
+		}
+	})
+}
+
+func TestAddSubNegModReduced(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		for i := 0; i < 100; i++ {
+			_, x, err := randHighNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, y, err := randHighNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			x.Mod(x, m)
+			y.Mod(y, m)
+			bx, by := x.ToBig(), y.ToBig()
+			requireEq(t, new(big.Int).Mod(new(big.Int).Add(bx, by), bm), new(Int).AddMod(x, y, m), "AddMod")
+			requireEq(t, new(big.Int).Mod(new(big.Int).Sub(bx, by), bm), new(Int).SubMod(x, y, m), "SubMod")
+			requireEq(t, new(big.Int).Mod(new(big.Int).Neg(bx), bm), new(Int).NegMod(x, m), "NegMod")
+			requireEq(t, new(big.Int).Mod(new(big.Int).Lsh(bx, 1), bm), new(Int).DoubleMod(x, m), "DoubleMod")
+			if bm.Bit(0) == 1 {
+				got := new(Int).HalveMod(x, m)
+				requireEq(t, bx, got.DoubleMod(got, m), "DoubleMod(HalveMod)")
+			}
+		}
+	}
+}
+
+func TestMultiExpMod(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		for _, n := range []int{0, 1, 2, 5} {
+			bases := make([]Int, n)
+			exps := make([]Int, n)
+			want := new(big.Int).Mod(big.NewInt(1), bm)
+			for i := range bases {
+				bx, x, _ := randHighNums()
+				be, e, _ := randNums()
+				bases[i], exps[i] = *x, *e
+				want.Mul(want, new(big.Int).Exp(bx, be, bm)).Mod(want, bm)
+			}
+			requireEq(t, want, new(Int).MultiExpMod(bases, exps, m), "MultiExpMod")
+		}
+	}
+	if got := new(Int).MultiExpMod([]Int{{2}}, []Int{{3}}, new(Int)); !got.IsZero() {
+		t.Errorf("MultiExpMod with zero modulus: got %v, want 0", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MultiExpMod with mismatched lengths did not panic")
+		}
+	}()
+	new(Int).MultiExpMod(make([]Int, 2), make([]Int, 1), NewInt(7))
+}
+
+func BenchmarkMultiExpMod(b *testing.B) {
+	m, _ := FromHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	bases := make([]Int, 8)
+	exps := make([]Int, 8)
+	for i := range bases {
+		_, x, _ := randHighNums()
+		_, e, _ := randHighNums()
+		bases[i], exps[i] = *x, *e
+	}
+	b.Run("MultiExpMod", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.MultiExpMod(bases, exps, m)
+		}
+	})
+	b.Run("ExpMod", func(b *testing.B) {
+		var z, t Int
+		for i := 0; i < b.N; i++ {
+			z.SetOne()
+			for j := range bases {
+				z.MulMod(&z, t.ExpMod(&bases[j], &exps[j], m), m)
+			}
+		}
+	})
+}
+
+func TestSetBytesMod(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		for _, n := range []int{0, 1, 32, 33, 48, 64, 80} {
+			b := make([]byte, n)
+			if _, err := rand.Read(b); err != nil {
+				t.Fatal(err)
+			}
+			wb := b
+			if len(wb) > 64 {
+				wb = wb[len(wb)-64:]
+			}
+			want := new(big.Int).SetBytes(wb)
+			want.Mod(want, bm)
+			requireEq(t, want, new(Int).SetBytesMod(b, m), fmt.Sprintf("SetBytesMod(%x, %x)", b, m))
+		}
+	}
+	if got := new(Int).SetBytesMod([]byte{1, 2, 3}, new(Int)); !got.IsZero() {
+		t.Errorf("SetBytesMod with zero modulus: got %v, want 0", got)
+	}
+}
+
+func TestRandMod(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		for i := 0; i < 20; i++ {
+			x, err := RandMod(rand.Reader, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !x.Lt(m) {
+				t.Fatalf("RandMod(%x) = %x, not below the modulus", m, x)
+			}
+		}
+	}
+	// The result is the 64 bytes read, reduced.
+	b := bytes.Repeat([]byte{0xa5}, 64)
+	m := NewInt(1000003)
+	want := new(big.Int).Mod(new(big.Int).SetBytes(b), m.ToBig())
+	got, err := RandMod(bytes.NewReader(b), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireEq(t, want, got, "RandMod")
+	if _, err := RandMod(rand.Reader, new(Int)); err != ErrZeroModulus {
+		t.Errorf("RandMod with zero modulus: got error %v, want %v", err, ErrZeroModulus)
+	}
+	if _, err := RandMod(bytes.NewReader(b[:63]), m); err == nil {
+		t.Errorf("RandMod with a short reader: got no error")
+	}
+}
+
+func TestReduce512(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		mod := NewModulus(m)
+		for i := 0; i < 50; i++ {
+			bhi, hi, _ := randNums()
+			blo, lo, _ := randHighNums()
+			if i == 0 {
+				hi, bhi = new(Int), new(big.Int)
+			}
+			want := new(big.Int).Lsh(bhi, 256)
+			want.Add(want, blo).Mod(want, bm)
+			requireEq(t, want, new(Int).Reduce512(hi, lo, m), "Reduce512")
+			requireEq(t, want, mod.Reduce512(new(Int), hi, lo), "Modulus.Reduce512")
+		}
+	}
+	if got := new(Int).Reduce512(NewInt(1), NewInt(2), new(Int)); !got.IsZero() {
+		t.Errorf("Reduce512 with zero modulus: got %v, want 0", got)
+	}
+}
+
+func TestExpModUint64(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		mod := NewModulus(m)
+		for i, e := range []uint64{0, 1, 2, 3, 17, 65537, 1<<63 + 5, ^uint64(0)} {
+			bx, x, _ := randHighNums()
+			if i == 0 {
+				bx, x = new(big.Int), new(Int)
+			}
+			want := new(big.Int).Exp(bx, new(big.Int).SetUint64(e), bm)
+			requireEq(t, want, new(Int).ExpModUint64(x, e, m), fmt.Sprintf("ExpModUint64(%x, %d, %x)", x, e, m))
+			requireEq(t, want, mod.ExpModUint64(new(Int), x, e), fmt.Sprintf("Modulus.ExpModUint64(%x, %d, %x)", x, e, m))
+		}
+	}
+	if got := new(Int).ExpModUint64(NewInt(3), 5, new(Int)); !got.IsZero() {
+		t.Errorf("ExpModUint64 with zero modulus: got %v, want 0", got)
+	}
+}
+
+func BenchmarkExpModUint64(b *testing.B) {
+	m, _ := FromHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	b.Run("ExpModUint64", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ExpModUint64(x, 5, m)
+		}
+	})
+	b.Run("ExpMod", func(b *testing.B) {
+		var z Int
+		e := NewInt(5)
+		for i := 0; i < b.N; i++ {
+			z.ExpMod(x, e, m)
+		}
+	})
+}
+
+func TestNewModulusChecked(t *testing.T) {
+	if _, err := NewModulusChecked(new(Int)); err != ErrZeroModulus {
+		t.Errorf("NewModulusChecked(0): got %v, want ErrZeroModulus", err)
+	}
+	mod, err := NewModulusChecked(NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mod.MulMod(new(Int), NewInt(3), NewInt(5)); !got.Eq(NewInt(1)) {
+		t.Errorf("MulMod(3, 5) mod 7: got %v, want 1", got)
+	}
+}
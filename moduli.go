@@ -0,0 +1,40 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// Predefined moduli of common curves and fields, set up once at package
+// initialization. Each function returns the same Modulus on every call;
+// like any Modulus it is immutable and safe for concurrent use.
+var (
+	secp256k1PMod = NewModulus(&secp256k1P)
+	secp256k1NMod = NewModulus(&Int{0xbfd25e8cd0364141, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff})
+	p256PMod      = NewModulus(&p256P)
+	bn254PMod     = NewModulus(&Int{0x3c208c16d87cfd47, 0x97816a916871ca8d, 0xb85045b68181585d, 0x30644e72e131a029})
+	bn254RMod     = NewModulus(&Int{0x43e1f593f0000001, 0x2833e84879b97091, 0xb85045b68181585d, 0x30644e72e131a029})
+	bls12381RMod  = NewModulus(&Int{0xffffffff00000001, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48})
+	goldilocksMod = NewModulus(&Int{0xffffffff00000001})
+)
+
+// Secp256k1P returns the field prime of secp256k1, 2**256 - 2**32 - 977.
+func Secp256k1P() *Modulus { return secp256k1PMod }
+
+// Secp256k1N returns the group order of secp256k1.
+func Secp256k1N() *Modulus { return secp256k1NMod }
+
+// P256P returns the field prime of NIST P-256,
+// 2**256 - 2**224 + 2**192 + 2**96 - 1.
+func P256P() *Modulus { return p256PMod }
+
+// BN254P returns the base field prime of the BN254 (alt_bn128) curve.
+func BN254P() *Modulus { return bn254PMod }
+
+// BN254R returns the scalar field prime, the group order, of BN254.
+func BN254R() *Modulus { return bn254RMod }
+
+// BLS12381R returns the scalar field prime, the group order, of BLS12-381.
+func BLS12381R() *Modulus { return bls12381RMod }
+
+// Goldilocks returns the prime 2**64 - 2**32 + 1.
+func Goldilocks() *Modulus { return goldilocksMod }
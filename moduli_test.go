@@ -0,0 +1,43 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPredefinedModuli(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		mod  *Modulus
+		want string
+	}{
+		{"Secp256k1P", Secp256k1P(), "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"},
+		{"Secp256k1N", Secp256k1N(), "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"},
+		{"P256P", P256P(), "0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff"},
+		{"BN254P", BN254P(), "0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47"},
+		{"BN254R", BN254R(), "0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"},
+		{"BLS12381R", BLS12381R(), "0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"},
+		{"Goldilocks", Goldilocks(), "0xffffffff00000001"},
+	} {
+		m := tc.mod.Value()
+		if got := m.Hex(); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+			continue
+		}
+		bm := m.ToBig()
+		if !bm.ProbablyPrime(20) {
+			t.Errorf("%s: not prime", tc.name)
+		}
+		if m[3] != 0 && tc.mod.special == nil && !tc.mod.barrett {
+			t.Errorf("%s: reciprocal not precomputed", tc.name)
+		}
+		_, x, _ := randHighNums()
+		_, y, _ := randHighNums()
+		want := new(big.Int).Mul(x.ToBig(), y.ToBig())
+		requireEq(t, want.Mod(want, bm), tc.mod.MulMod(new(Int), x, y), tc.name+" MulMod")
+	}
+}
@@ -0,0 +1,175 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrEvenModulus is returned by constructors that need an odd modulus,
+// such as NewMontContext, when given an even one.
+var ErrEvenModulus = errors.New("modulus is even")
+
+// MontInt is a value in Montgomery form x*R mod m, with R = 2**256, for
+// the MontContext that produced it. Its methods are those of the context.
+type MontInt Int
+
+// MontContext holds the constants for Montgomery arithmetic modulo an odd
+// m. For long chains of multiplications, such as exponentiation or field
+// arithmetic, converting the operands to Montgomery form once and
+// multiplying with MontMul avoids a division per product. A MontContext is
+// immutable and safe for concurrent use.
+type MontContext struct {
+	m    Int
+	mInv uint64 // -m**-1 mod 2**64
+	r2   Int    // R**2 mod m
+}
+
+// NewMontContext returns a Montgomery context for the odd modulus m. It
+// returns ErrZeroModulus if m == 0, and ErrEvenModulus if m is even.
+func NewMontContext(m *Int) (*MontContext, error) {
+	if m.IsZero() {
+		return nil, ErrZeroModulus
+	}
+	if m[0]&1 == 0 {
+		return nil, ErrEvenModulus
+	}
+	return new(MontContext).init(m), nil
+}
+
+// init sets up c for the odd modulus m and returns c.
+func (c *MontContext) init(m *Int) *MontContext {
+	*c = MontContext{m: *m}
+	// Newton iteration for m[0]**-1 mod 2**64: each step doubles the
+	// number of correct low bits, starting from 1 (any odd number is its
+	// own inverse mod 2).
+	inv := m[0]
+	for i := 0; i < 6; i++ {
+		inv *= 2 - m[0]*inv
+	}
+	c.mInv = -inv
+	// R mod m = (2**256 - m) mod m, squared.
+	var r Int
+	r.Neg(m).Mod(&r, m)
+	c.r2.MulMod(&r, &r, m)
+	return c
+}
+
+// Modulus returns the modulus of the context.
+func (c *MontContext) Modulus() *Int {
+	return c.m.Clone()
+}
+
+// montMul sets z to x*y/R mod m, for x, y < m, using the coarsely
+// integrated operand scanning (CIOS) method.
+func (c *MontContext) montMul(z, x, y *Int) {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		var carry, hi, lo, cc uint64
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j], carry = lo, hi
+		}
+		t[4], cc = bits.Add64(t[4], carry, 0)
+		t[5] = cc
+
+		mm := t[0] * c.mInv
+		hi, lo = bits.Mul64(mm, c.m[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		carry = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(mm, c.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j-1], carry = lo, hi
+		}
+		t[3], cc = bits.Add64(t[4], carry, 0)
+		t[4] = t[5] + cc
+	}
+	// The result t < 2m; subtract m once if needed. The selection is
+	// branch-free so that ExpModCT does not leak through it.
+	var r Int
+	var borrow uint64
+	r[0], borrow = bits.Sub64(t[0], c.m[0], 0)
+	r[1], borrow = bits.Sub64(t[1], c.m[1], borrow)
+	r[2], borrow = bits.Sub64(t[2], c.m[2], borrow)
+	r[3], borrow = bits.Sub64(t[3], c.m[3], borrow)
+	// Keep t if t < m, i.e. t[4] == 0 and the subtraction borrowed.
+	mask := -(borrow &^ t[4])
+	z[0] = r[0] ^ ((t[0] ^ r[0]) & mask)
+	z[1] = r[1] ^ ((t[1] ^ r[1]) & mask)
+	z[2] = r[2] ^ ((t[2] ^ r[2]) & mask)
+	z[3] = r[3] ^ ((t[3] ^ r[3]) & mask)
+}
+
+// montSqr sets z = x*x*R**-1 mod m, for x < m, by a full squaring
+// followed by Montgomery reduction of the 512-bit product.
+func (c *MontContext) montSqr(z, x *Int) {
+	t := usqr(x)
+	var top uint64
+	for i := 0; i < 4; i++ {
+		mm := t[i] * c.mInv
+		var carry, hi, lo, cc uint64
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(mm, c.m[j])
+			lo, cc = bits.Add64(lo, t[i+j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[i+j], carry = lo, hi
+		}
+		// The carry out of the previous row belongs at the same position.
+		t[i+4], top = bits.Add64(t[i+4], carry, top)
+	}
+	// The result (top, t[4..7]) < 2m; subtract m once if needed, without
+	// branching.
+	var r Int
+	var borrow uint64
+	r[0], borrow = bits.Sub64(t[4], c.m[0], 0)
+	r[1], borrow = bits.Sub64(t[5], c.m[1], borrow)
+	r[2], borrow = bits.Sub64(t[6], c.m[2], borrow)
+	r[3], borrow = bits.Sub64(t[7], c.m[3], borrow)
+	mask := -(borrow &^ top)
+	z[0] = r[0] ^ ((t[4] ^ r[0]) & mask)
+	z[1] = r[1] ^ ((t[5] ^ r[1]) & mask)
+	z[2] = r[2] ^ ((t[6] ^ r[2]) & mask)
+	z[3] = r[3] ^ ((t[7] ^ r[3]) & mask)
+}
+
+// ToMont sets z to the Montgomery form of x and returns z.
+func (c *MontContext) ToMont(z *MontInt, x *Int) *MontInt {
+	var xr Int
+	xr.Mod(x, &c.m)
+	c.montMul((*Int)(z), &xr, &c.r2)
+	return z
+}
+
+// FromMont sets z to the value of the Montgomery form x, and returns z.
+func (c *MontContext) FromMont(z *Int, x *MontInt) *Int {
+	var one Int
+	one.Mod(&Int{1}, &c.m)
+	c.montMul(z, (*Int)(x), &one)
+	return z
+}
+
+// MontMul sets z to the Montgomery product of x and y, the Montgomery form
+// of their product, and returns z.
+func (c *MontContext) MontMul(z, x, y *MontInt) *MontInt {
+	c.montMul((*Int)(z), (*Int)(x), (*Int)(y))
+	return z
+}
+
+// MontSqr sets z to the Montgomery form of the square of x, and returns z.
+func (c *MontContext) MontSqr(z, x *MontInt) *MontInt {
+	c.montSqr((*Int)(z), (*Int)(x))
+	return z
+}
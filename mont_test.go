@@ -0,0 +1,79 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMontgomery(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		if bm.Bit(0) == 0 {
+			bm = new(big.Int).Add(bm, big.NewInt(1))
+			if bm.BitLen() > 256 {
+				continue
+			}
+		}
+		m, _ := FromBig(bm)
+		c, err := NewMontContext(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !c.Modulus().Eq(m) {
+			t.Fatalf("Modulus: got %v, want %v", c.Modulus(), m)
+		}
+		for i := 0; i < 50; i++ {
+			bx, x, _ := randNums()
+			by, y, _ := randHighNums()
+			var mx, my, mz MontInt
+			c.ToMont(&mx, x)
+			c.ToMont(&my, y)
+
+			var got Int
+			c.FromMont(&got, &mx)
+			requireEq(t, new(big.Int).Mod(bx, bm), &got, "FromMont(ToMont)")
+
+			c.FromMont(&got, c.MontMul(&mz, &mx, &my))
+			requireEq(t, new(big.Int).Mod(new(big.Int).Mul(bx, by), bm), &got, "MontMul")
+
+			c.FromMont(&got, c.MontSqr(&mz, &my))
+			requireEq(t, new(big.Int).Mod(new(big.Int).Mul(by, by), bm), &got, "MontSqr")
+
+			// The Montgomery form itself is x*2**256 mod m.
+			want := new(big.Int).Mod(new(big.Int).Lsh(bx, 256), bm)
+			requireEq(t, want, (*Int)(&mx), "ToMont")
+		}
+	}
+	for _, m := range []*Int{NewInt(2), new(Int).Lsh(NewInt(1), 255)} {
+		if _, err := NewMontContext(m); err != ErrEvenModulus {
+			t.Errorf("NewMontContext(%v): got %v, want ErrEvenModulus", m, err)
+		}
+	}
+	if _, err := NewMontContext(new(Int)); err != ErrZeroModulus {
+		t.Errorf("NewMontContext(0): got %v, want ErrZeroModulus", err)
+	}
+}
+
+func BenchmarkMontMul(b *testing.B) {
+	m, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	c, _ := NewMontContext(m)
+	var mx MontInt
+	c.ToMont(&mx, x)
+	for i := 0; i < b.N; i++ {
+		c.MontMul(&mx, &mx, &mx)
+	}
+}
+func BenchmarkMontSqr(b *testing.B) {
+	m, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	c, _ := NewMontContext(m)
+	var mx MontInt
+	c.ToMont(&mx, x)
+	for i := 0; i < b.N; i++ {
+		c.MontSqr(&mx, &mx)
+	}
+}
@@ -0,0 +1,125 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package ntt implements the number-theoretic transform over prime fields
+// of up to 256 bits, for fast polynomial multiplication.
+//
+// A transform of size n modulo p needs a primitive n-th root of unity,
+// which exists when n divides p-1. The common NTT-friendly primes are
+// available as uint256.BN254R() (n up to 2**28, generator 5),
+// uint256.BLS12381R() (2**32, generator 7) and uint256.Goldilocks() (2**32,
+// generator 7).
+//
+// The transforms are computed by uint256.NTT, and so by the installed
+// uint256.BatchBackend.
+package ntt
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+var (
+	ErrSize   = errors.New("ntt: size is not a power of two dividing p-1")
+	ErrNoRoot = errors.New("ntt: no primitive root of unity of the requested order")
+	ErrLength = errors.New("ntt: input length does not match the domain")
+)
+
+// Domain holds the roots of unity for transforms of one size modulo one
+// prime. A Domain is immutable and safe for concurrent use.
+type Domain struct {
+	mod      *uint256.Modulus
+	p        uint256.Int
+	n        int
+	omega    uint256.Int // primitive n-th root of unity
+	omegaInv uint256.Int
+	nInv     uint256.Int
+}
+
+// NewDomain returns a Domain for transforms of size n modulo the prime in
+// mod, using the n-th root of unity g**((p-1)/n). g is normally a
+// generator of the multiplicative group modulo p. It returns ErrSize if n
+// is not a power of two dividing p-1, and ErrNoRoot if g does not yield a
+// primitive n-th root of unity.
+func NewDomain(mod *uint256.Modulus, g *uint256.Int, n int) (*Domain, error) {
+	p := mod.Value()
+	var pm1, q, r uint256.Int
+	pm1.SubUint64(p, 1)
+	if n <= 0 || n&(n-1) != 0 || p.IsZero() {
+		return nil, ErrSize
+	}
+	q.Div(&pm1, uint256.NewInt(uint64(n)))
+	if !r.Mod(&pm1, uint256.NewInt(uint64(n))).IsZero() {
+		return nil, ErrSize
+	}
+	var omega, t uint256.Int
+	mod.ExpMod(&omega, g, &q)
+	// omega is a primitive n-th root of unity iff omega**(n/2) = -1.
+	if n > 1 && !mod.ExpMod(&t, &omega, uint256.NewInt(uint64(n/2))).Eq(&pm1) {
+		return nil, ErrNoRoot
+	}
+	if n == 1 && !omega.Eq(uint256.NewInt(1)) {
+		return nil, ErrNoRoot
+	}
+	d := &Domain{mod: mod, p: *p, n: n, omega: omega}
+	d.omegaInv.ModInverse(&omega, p)
+	d.nInv.ModInverse(uint256.NewInt(uint64(n)), p)
+	return d, nil
+}
+
+// Size returns the size n of the transforms of the domain.
+func (d *Domain) Size() int {
+	return d.n
+}
+
+// Forward replaces a, the coefficients of a polynomial of degree less
+// than n, by its values at omega**i for i < n, in natural order. The
+// elements of a need not be reduced. It returns ErrLength if len(a) != n.
+func (d *Domain) Forward(a []uint256.Int) error {
+	if len(a) != d.n {
+		return ErrLength
+	}
+	uint256.NTT(a, &d.omega, &d.p)
+	return nil
+}
+
+// Inverse undoes Forward: it replaces the values at omega**i in a by the
+// coefficients of the polynomial. It returns ErrLength if len(a) != n.
+func (d *Domain) Inverse(a []uint256.Int) error {
+	if len(a) != d.n {
+		return ErrLength
+	}
+	uint256.NTT(a, &d.omegaInv, &d.p)
+	for i := range a {
+		d.mod.MulMod(&a[i], &a[i], &d.nInv)
+	}
+	return nil
+}
+
+// Mul returns the product of the polynomials with coefficients a and b,
+// modulo p. The product must have fewer than n coefficients, that is,
+// len(a)+len(b)-1 <= n, or Mul returns ErrLength.
+func (d *Domain) Mul(a, b []uint256.Int) ([]uint256.Int, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, nil
+	}
+	l := len(a) + len(b) - 1
+	if l > d.n {
+		return nil, ErrLength
+	}
+	fa := make([]uint256.Int, d.n)
+	fb := make([]uint256.Int, d.n)
+	copy(fa, a)
+	copy(fb, b)
+	uint256.NTT(fa, &d.omega, &d.p)
+	uint256.NTT(fb, &d.omega, &d.p)
+	for i := range fa {
+		d.mod.MulMod(&fa[i], &fa[i], &fb[i])
+	}
+	if err := d.Inverse(fa); err != nil {
+		return nil, err
+	}
+	return fa[:l], nil
+}
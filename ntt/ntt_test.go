@@ -0,0 +1,138 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ntt
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func randInts(t testing.TB, n int) []uint256.Int {
+	res := make([]uint256.Int, n)
+	b := make([]byte, 32)
+	for i := range res {
+		if _, err := rand.Read(b); err != nil {
+			t.Fatal(err)
+		}
+		res[i].SetBytes(b)
+	}
+	return res
+}
+
+var fields = []struct {
+	name string
+	mod  *uint256.Modulus
+	g    uint64
+}{
+	{"BN254R", uint256.BN254R(), 5},
+	{"BLS12381R", uint256.BLS12381R(), 7},
+	{"Goldilocks", uint256.Goldilocks(), 7},
+}
+
+func TestForward(t *testing.T) {
+	for _, f := range fields {
+		p := f.mod.Value()
+		for _, n := range []int{1, 2, 4, 16} {
+			d, err := NewDomain(f.mod, uint256.NewInt(f.g), n)
+			if err != nil {
+				t.Fatalf("%s: NewDomain(%d): %v", f.name, n, err)
+			}
+			coeffs := randInts(t, n)
+			a := append([]uint256.Int(nil), coeffs...)
+			if err := d.Forward(a); err != nil {
+				t.Fatal(err)
+			}
+			// The i-th value is the polynomial at omega**i.
+			var omega, x, q, want uint256.Int
+			q.SubUint64(p, 1).Div(&q, uint256.NewInt(uint64(n)))
+			f.mod.ExpMod(&omega, uint256.NewInt(f.g), &q)
+			x.SetOne()
+			for i := range a {
+				want.EvalPolyMod(coeffs, &x, p)
+				if !a[i].Eq(&want) {
+					t.Fatalf("%s: n=%d: value %d: got %x, want %x", f.name, n, i, &a[i], &want)
+				}
+				f.mod.MulMod(&x, &x, &omega)
+			}
+			if err := d.Inverse(a); err != nil {
+				t.Fatal(err)
+			}
+			for i := range a {
+				f.mod.Reduce(&want, &coeffs[i])
+				if !a[i].Eq(&want) {
+					t.Fatalf("%s: n=%d: inverse coefficient %d: got %x, want %x", f.name, n, i, &a[i], &want)
+				}
+			}
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	for _, f := range fields {
+		p := f.mod.Value()
+		d, err := NewDomain(f.mod, uint256.NewInt(f.g), 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, lens := range [][2]int{{1, 1}, {3, 5}, {32, 33}, {1, 64}} {
+			a, b := randInts(t, lens[0]), randInts(t, lens[1])
+			got, err := d.Mul(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make([]uint256.Int, len(a)+len(b)-1)
+			for i := range a {
+				for j := range b {
+					want[i+j].MulAddMod(&a[i], &b[j], &want[i+j], p)
+				}
+			}
+			if len(got) != len(want) {
+				t.Fatalf("%s: Mul: got %d coefficients, want %d", f.name, len(got), len(want))
+			}
+			for i := range want {
+				if !got[i].Eq(&want[i]) {
+					t.Fatalf("%s: Mul %v: coefficient %d: got %x, want %x", f.name, lens, i, &got[i], &want[i])
+				}
+			}
+		}
+		if _, err := d.Mul(randInts(t, 33), randInts(t, 33)); err != ErrLength {
+			t.Errorf("%s: Mul of too long inputs: got error %v, want %v", f.name, err, ErrLength)
+		}
+	}
+}
+
+func TestNewDomainErrors(t *testing.T) {
+	for _, tc := range []struct {
+		g    uint64
+		n    int
+		want error
+	}{
+		{5, 0, ErrSize},
+		{5, 3, ErrSize},
+		{5, 1 << 29, ErrSize},
+		{1, 4, ErrNoRoot},
+	} {
+		if _, err := NewDomain(uint256.BN254R(), uint256.NewInt(tc.g), tc.n); err != tc.want {
+			t.Errorf("NewDomain(BN254R, %d, %d): got error %v, want %v", tc.g, tc.n, err, tc.want)
+		}
+	}
+	d, _ := NewDomain(uint256.BN254R(), uint256.NewInt(5), 4)
+	if err := d.Forward(make([]uint256.Int, 3)); err != ErrLength {
+		t.Errorf("Forward of the wrong length: got error %v, want %v", err, ErrLength)
+	}
+}
+
+func BenchmarkForward(b *testing.B) {
+	d, err := NewDomain(uint256.BN254R(), uint256.NewInt(5), 1024)
+	if err != nil {
+		b.Fatal(err)
+	}
+	a := randInts(b, 1024)
+	for i := 0; i < b.N; i++ {
+		d.Forward(a)
+	}
+}
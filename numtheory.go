@@ -0,0 +1,824 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math"
+	"math/bits"
+)
+
+// subModReduced sets z to (x - y) mod m, for x, y < m, and returns z.
+func (z *Int) subModReduced(x, y, m *Int) *Int {
+	var borrow uint64
+	z[0], borrow = bits.Sub64(x[0], y[0], 0)
+	z[1], borrow = bits.Sub64(x[1], y[1], borrow)
+	z[2], borrow = bits.Sub64(x[2], y[2], borrow)
+	z[3], borrow = bits.Sub64(x[3], y[3], borrow)
+	if borrow != 0 {
+		z.Add(z, m)
+	}
+	return z
+}
+
+// LucasMod returns the Lucas sequence values U_n(P, Q) and V_n(P, Q)
+// modulo m, defined by U_0 = 0, U_1 = 1, V_0 = 2, V_1 = P and
+// X_k = P*X_(k-1) - Q*X_(k-2). A negative Q is passed as m - |Q|.
+// The values are computed by fast doubling on (U_k, U_k+1), which needs
+// no division and therefore works for even m too.
+// If m == 0, both results are 0 (OBS: differs from the big.Int)
+func LucasMod(n, p, q, m *Int) (u, v Int) {
+	if m.IsZero() {
+		return u, v
+	}
+	var pm, qm Int
+	pm.Mod(p, m)
+	qm.Mod(q, m)
+
+	var (
+		u0 Int // U_k
+		u1 Int // U_k+1
+		t  Int
+	)
+	u1.Mod(&Int{1}, m)
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		// U_2k = U_k * (2*U_k+1 - P*U_k)
+		// U_2k+1 = U_k+1^2 - Q*U_k^2
+		var a, b Int
+		a.AddMod(&u1, &u1, m)
+		t.MulMod(&pm, &u0, m)
+		a.subModReduced(&a, &t, m)
+		a.MulMod(&a, &u0, m)
+
+		b.MulMod(&u1, &u1, m)
+		t.MulMod(&u0, &u0, m)
+		t.MulMod(&t, &qm, m)
+		b.subModReduced(&b, &t, m)
+
+		if n.isBitSet(uint(i)) {
+			// U_2k+2 = P*U_2k+1 - Q*U_2k
+			u0 = b
+			u1.MulMod(&pm, &b, m)
+			t.MulMod(&qm, &a, m)
+			u1.subModReduced(&u1, &t, m)
+		} else {
+			u0, u1 = a, b
+		}
+	}
+	// V_n = 2*U_n+1 - P*U_n
+	v.AddMod(&u1, &u1, m)
+	t.MulMod(&pm, &u0, m)
+	v.subModReduced(&v, &t, m)
+	return u0, v
+}
+
+// FibonacciMod sets z to the n-th Fibonacci number modulo m and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) FibonacciMod(n, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	var negOne Int
+	negOne.SubUint64(m, 1)
+	u, _ := LucasMod(n, &Int{1}, &negOne, m)
+	return z.Set(&u)
+}
+
+// InvModPrime sets z to x**(p-2) mod p, the inverse of x modulo the prime
+// p, and returns z. If x is 0 mod p, z is set to 0. For p pinned with
+// RegisterFixedModulus, a precomputed addition chain is used; see
+// Modulus.InvModPrime.
+// If p < 2, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) InvModPrime(x, p *Int) *Int {
+	if mod := fixedModulus(p); mod != nil {
+		return mod.InvModPrime(z, x)
+	}
+	var mod Modulus
+	return mod.init(p).InvModPrime(z, x)
+}
+
+// FactorialMod sets z to n! mod m and returns z.
+// The cost is n modular multiplications, so n should be moderate. For
+// n >= m the result is 0, since m is then one of the factors.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) FactorialMod(n uint64, m *Int) *Int {
+	if instrumented {
+		defer region("FactorialMod")()
+	}
+	if m.IsZero() || !m.GtUint64(n) {
+		return z.Clear()
+	}
+	var res, f Int
+	res.Mod(&Int{1}, m)
+	for i := uint64(2); i <= n; i++ {
+		res.MulMod(&res, f.SetUint64(i), m)
+	}
+	return z.Set(&res)
+}
+
+// BinomialMod sets z to the binomial coefficient C(n, k) modulo the prime p
+// and returns z.
+// For n >= p Lucas' theorem is applied to the base-p digits of n and k.
+// The cost is O(min(k, n-k)) modular multiplications plus one inversion;
+// use a FactorialTable for many queries with the same p.
+// If p == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) BinomialMod(n, k uint64, p *Int) *Int {
+	if p.IsZero() || k > n {
+		return z.Clear()
+	}
+	if p.GtUint64(n) {
+		return z.binomialSmall(n, k, p)
+	}
+	// Lucas: C(n, k) = prod C(n_i, k_i) mod p over the base-p digits.
+	var res, t Int
+	pw := p.Uint64()
+	res.Mod(&Int{1}, p)
+	for n > 0 && !res.IsZero() {
+		ni, ki := n%pw, k%pw
+		if ki > ni {
+			return z.Clear()
+		}
+		res.MulMod(&res, t.binomialSmall(ni, ki, p), p)
+		n, k = n/pw, k/pw
+	}
+	return z.Set(&res)
+}
+
+// binomialSmall sets z to C(n, k) mod p for k <= n < p and returns z.
+func (z *Int) binomialSmall(n, k uint64, p *Int) *Int {
+	if n-k < k {
+		k = n - k
+	}
+	var num, den, f Int
+	num.Mod(&Int{1}, p)
+	den.Set(&num)
+	for i := uint64(0); i < k; i++ {
+		num.MulMod(&num, f.SetUint64(n-i), p)
+		den.MulMod(&den, f.SetUint64(i+1), p)
+	}
+	den.InvModPrime(&den, p)
+	return z.MulMod(&num, &den, p)
+}
+
+// FactorialTable holds the factorials and inverse factorials 0!..n! modulo
+// a prime p, for answering many factorial and binomial queries cheaply.
+type FactorialTable struct {
+	p       Int
+	fact    []Int
+	invFact []Int
+}
+
+// NewFactorialTable precomputes the factorials up to n! modulo the prime p,
+// using n multiplications for each table and a single inversion.
+// It panics if p <= n, since n! would then be 0 and not invertible.
+func NewFactorialTable(n uint64, p *Int) *FactorialTable {
+	if instrumented {
+		defer region("NewFactorialTable")()
+	}
+	if !p.GtUint64(n) {
+		panic("uint256: factorial table size must be smaller than the modulus")
+	}
+	t := &FactorialTable{
+		p:       *p,
+		fact:    make([]Int, n+1),
+		invFact: make([]Int, n+1),
+	}
+	var f Int
+	t.fact[0].Mod(&Int{1}, p)
+	for i := uint64(1); i <= n; i++ {
+		t.fact[i].MulMod(&t.fact[i-1], f.SetUint64(i), p)
+	}
+	t.invFact[n].InvModPrime(&t.fact[n], p)
+	for i := n; i > 0; i-- {
+		t.invFact[i-1].MulMod(&t.invFact[i], f.SetUint64(i), p)
+	}
+	return t
+}
+
+// Factorial sets z to n! mod p and returns z. It panics if n is larger
+// than the table.
+func (t *FactorialTable) Factorial(z *Int, n uint64) *Int {
+	return z.Set(&t.fact[n])
+}
+
+// Binomial sets z to C(n, k) mod p and returns z. It panics if n is larger
+// than the table.
+func (t *FactorialTable) Binomial(z *Int, n, k uint64) *Int {
+	if k > n {
+		return z.Clear()
+	}
+	var res Int
+	res.MulMod(&t.fact[n], &t.invFact[k], &t.p)
+	res.MulMod(&res, &t.invFact[n-k], &t.p)
+	return z.Set(&res)
+}
+
+// DiscreteLog returns the smallest x <= bound such that base**x = target
+// (mod m), using the baby-step giant-step algorithm. It performs about
+// 2*sqrt(bound) modular multiplications and keeps sqrt(bound) values in a
+// hash table, which limits it to bounds of up to around 2**60.
+// The second return value reports whether such an x was found.
+func DiscreteLog(base, target, m *Int, bound uint64) (uint64, bool) {
+	if instrumented {
+		defer region("DiscreteLog")()
+	}
+	if m.IsZero() {
+		return 0, false
+	}
+	var b, t Int
+	b.Mod(base, m)
+	t.Mod(target, m)
+
+	// n = ceil(sqrt(bound + 1)), so that n*n > bound.
+	n := uint64(math.Sqrt(float64(bound)))
+	for n > 0 && n*n > bound {
+		n--
+	}
+	for n*n <= bound && n < 1<<32 {
+		n++
+	}
+
+	// Baby steps: target * base^j for j in [0, n].
+	table := make(map[Int]uint64, n+1)
+	cur := t
+	for j := uint64(0); j <= n; j++ {
+		table[cur] = j
+		cur.MulMod(&cur, &b, m)
+	}
+	// Giant steps: base^(i*n) = target * base^j gives x = i*n - j.
+	var g, giant, check Int
+	g.ExpMod(&b, new(Int).SetUint64(n), m)
+	giant.Set(&g)
+	for i := uint64(1); i <= n; i++ {
+		if j, ok := table[giant]; ok {
+			x := i*n - j
+			// If base is not invertible mod m, the match may be spurious.
+			if x <= bound && check.ExpMod(&b, new(Int).SetUint64(x), m).Eq(&t) {
+				return x, true
+			}
+		}
+		giant.MulMod(&giant, &g, m)
+	}
+	return 0, false
+}
+
+// PrimePower is the prime power P**E, one term of a factorization.
+type PrimePower struct {
+	P Int
+	E uint
+}
+
+// factorProduct returns the product of the prime powers in f.
+func factorProduct(f []PrimePower) Int {
+	res := Int{1}
+	for i := range f {
+		for j := uint(0); j < f[i].E; j++ {
+			res.Mul(&res, &f[i].P)
+		}
+	}
+	return res
+}
+
+// Order sets z to the multiplicative order of a modulo m and returns z.
+// factoredPhi is the factorization of the group order phi(m) (or of any
+// multiple of the order of a, such as the Carmichael function of m).
+// If a is not a unit modulo m, z is set to 0.
+func (z *Int) Order(a, m *Int, factoredPhi []PrimePower) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	one := new(Int).Mod(&Int{1}, m)
+	t := factorProduct(factoredPhi)
+	var x Int
+	if !x.ExpMod(a, &t, m).Eq(one) {
+		return z.Clear()
+	}
+	for i := range factoredPhi {
+		p := &factoredPhi[i].P
+		// Remove p from t as long as a^t stays 1.
+		for j := uint(0); j < factoredPhi[i].E; j++ {
+			var q Int
+			q.Div(&t, p)
+			if !x.ExpMod(a, &q, m).Eq(one) {
+				break
+			}
+			t = q
+		}
+	}
+	return z.Set(&t)
+}
+
+// PrimitiveRoot sets z to the smallest generator of the multiplicative
+// group modulo the prime p, given the factorization of p-1, and returns z.
+// A candidate g is a generator if g**((p-1)/q) != 1 for every prime q
+// dividing p-1.
+// The result is only meaningful if p is prime and the factorization is
+// correct. If no candidate passes, z is set to 0.
+func (z *Int) PrimitiveRoot(p *Int, factoredPMinus1 []PrimePower) *Int {
+	if p.LtUint64(3) {
+		if p.Eq(&Int{2}) {
+			return z.SetOne()
+		}
+		return z.Clear()
+	}
+	var pm1 Int
+	pm1.SubUint64(p, 1)
+	exps := make([]Int, len(factoredPMinus1))
+	for i := range factoredPMinus1 {
+		exps[i].Div(&pm1, &factoredPMinus1[i].P)
+	}
+	var g, x Int
+	for g.SetUint64(2); g.Lt(p); g.AddUint64(&g, 1) {
+		ok := true
+		for i := range exps {
+			if x.ExpMod(&g, &exps[i], p).Eq(&Int{1}) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return z.Set(&g)
+		}
+	}
+	return z.Clear()
+}
+
+// ModInverse sets z to the multiplicative inverse of x modulo m, and
+// returns z and true. If gcd(x, m) != 1 there is no inverse, and z is set
+// to 0 and false is returned.
+// If m == 0, z is set to 0 and false is returned (OBS: differs from the big.Int)
+func (z *Int) ModInverse(x, m *Int) (*Int, bool) {
+	if m.IsZero() {
+		return z.Clear(), false
+	}
+	// Extended Euclid, tracking only the coefficients of x. They alternate
+	// in sign and their magnitudes u0, u1 never exceed m, so the magnitudes
+	// are kept together with the sign of u0.
+	var a, b, q, r, u0, u1, t Int
+	a = *m
+	b.Mod(x, m)
+	u1.SetOne()
+	// neg is the sign of the coefficient u0; u1 = 1 starts out positive.
+	neg := true
+	for !b.IsZero() {
+		q.Div(&a, &b)
+		r.Sub(&a, t.Mul(&q, &b))
+		a, b = b, r
+		t.Add(&u0, t.Mul(&q, &u1))
+		u0, u1 = u1, t
+		neg = !neg
+	}
+	if !a.Eq(&Int{1}) {
+		return z.Clear(), false
+	}
+	if neg && !u0.IsZero() {
+		u0.Sub(m, &u0)
+	}
+	return z.Set(&u0), true
+}
+
+// ModSqrt sets z to a square root of x modulo the odd prime p, and returns
+// z and true. If x is not a square modulo p, z is set to 0 and false is
+// returned. Primes p = 3 mod 4 take a single exponentiation; other primes
+// use the Tonelli-Shanks algorithm. The result is unspecified if p is not
+// prime.
+// If p is even, including p == 0, z is set to 0 and false is returned (OBS: differs from the big.Int)
+func (z *Int) ModSqrt(x, p *Int) (*Int, bool) {
+	if p[0]&1 == 0 {
+		return z.Clear(), false
+	}
+	var mod Modulus
+	mod.init(p)
+	var a, r, e Int
+	mod.Reduce(&a, x)
+	if a.IsZero() {
+		return z.Clear(), true
+	}
+	if p[0]&3 == 3 {
+		// r = a**((p+1)/4), which is a root if a is a square.
+		e.Rsh(p, 2)
+		mod.ExpMod(&r, &a, e.AddUint64(&e, 1))
+	} else if !r.tonelliShanks(&a, &mod) {
+		return z.Clear(), false
+	}
+	if !e.MulMod(&r, &r, p).Eq(&a) {
+		return z.Clear(), false
+	}
+	return z.Set(&r), true
+}
+
+// tonelliShanks sets z to a square root of the reduced, nonzero a modulo
+// the odd prime in mod, and returns whether one was found.
+func (z *Int) tonelliShanks(a *Int, mod *Modulus) bool {
+	p := mod.Value()
+	var one, pm1, q, e Int
+	one.SetOne()
+	pm1.SubUint64(p, 1)
+	// p-1 = q * 2**s with q odd.
+	s := uint(0)
+	for !pm1.isBitSet(s) {
+		s++
+	}
+	q.Rsh(&pm1, s)
+	var r, t, b, c Int
+	mod.ExpMod(&t, a, &q)
+	mod.ExpMod(&r, a, e.Rsh(e.AddUint64(&q, 1), 1))
+	if t.Eq(&one) {
+		z.Set(&r)
+		return true
+	}
+	// Find a non-residue n by Euler's criterion, n**((p-1)/2) = -1. Any
+	// value other than 1 or -1 shows that p is not prime.
+	var n Int
+	e.Rsh(&pm1, 1)
+	for n.SetUint64(2); !mod.ExpMod(&c, &n, &e).Eq(&pm1); n.AddUint64(&n, 1) {
+		if !c.Eq(&one) {
+			return false
+		}
+	}
+	// Invariant: r**2 = a*t, c**(2**(m-1)) = -1, t**(2**(m-1)) = 1.
+	mod.ExpMod(&c, &n, &q)
+	m := s
+	for !t.Eq(&one) {
+		// Find the least i with t**(2**i) = 1.
+		i := uint(0)
+		for b = t; !b.Eq(&one); i++ {
+			if i+1 == m {
+				return false
+			}
+			mod.MulMod(&b, &b, &b)
+		}
+		b = c
+		for j := i + 1; j < m; j++ {
+			mod.MulMod(&b, &b, &b)
+		}
+		m = i
+		mod.MulMod(&c, &b, &b)
+		mod.MulMod(&t, &t, &c)
+		mod.MulMod(&r, &r, &b)
+	}
+	z.Set(&r)
+	return true
+}
+
+// ModRoot sets z to an n-th root of x modulo the odd prime p, a value r
+// with r**n = x mod p, and returns z and true. If x has no n-th root modulo
+// p, z is set to 0 and false is returned. With d = gcd(n, p-1), the root is
+// a single exponentiation when d = 1; otherwise a d-th root is taken for
+// each prime power dividing d in turn, with the Adleman-Manders-Miller
+// method. The prime factors of d are found by trial division up to 2**16,
+// so a root may be missed if d has two prime factors above that. A root
+// may also be missed for a prime factor q >= 2**32 of d with q**2 dividing
+// p-1, as the method then needs a discrete logarithm in the subgroup of
+// order q, which is only taken below that size. For
+// n == 0, only x = 1 has roots, and z is set to 1. The result is
+// unspecified if p is not prime.
+// If p is even, including p == 0, z is set to 0 and false is returned (OBS: differs from the big.Int)
+func (z *Int) ModRoot(x, n, p *Int) (*Int, bool) {
+	if p[0]&1 == 0 {
+		return z.Clear(), false
+	}
+	var mod Modulus
+	mod.init(p)
+	var a Int
+	mod.Reduce(&a, x)
+	if n.IsZero() {
+		if a.Eq(&Int{1}) {
+			return z.SetOne(), true
+		}
+		return z.Clear(), false
+	}
+	if a.IsZero() {
+		return z.Clear(), true
+	}
+	// a has an n-th root iff it has a d-th root, iff a**((p-1)/d) = 1.
+	var pm1, d, k, t Int
+	pm1.SubUint64(p, 1)
+	d.GCD(n, &pm1)
+	k.Div(&pm1, &d)
+	if !mod.ExpMod(&t, &a, &k).Eq(&Int{1}) {
+		return z.Clear(), false
+	}
+	// With u = (n/d)**-1 mod k, a d-th root of a**u is an n-th root of a,
+	// since the order of a divides k.
+	var u, r Int
+	t.Div(n, &d)
+	u.ModInverse(t.Mod(&t, &k), &k)
+	mod.ExpMod(&r, &a, &u)
+	for _, f := range trialFactor(&d, 1<<16) {
+		if !r.rootPrimePower(&r, &f, &mod) {
+			return z.Clear(), false
+		}
+	}
+	if !mod.ExpMod(&t, &r, n).Eq(&a) {
+		return z.Clear(), false
+	}
+	return z.Set(&r), true
+}
+
+// trialFactor returns the factorization of the nonzero x by trial division
+// up to bound. A remaining cofactor is returned as if it were prime, which
+// it is unless it has two prime factors above bound.
+func trialFactor(x *Int, bound uint64) []PrimePower {
+	var res []PrimePower
+	var rem, q, r, f Int
+	rem.Set(x)
+	for i := uint64(2); i <= bound; i++ {
+		f.SetUint64(i)
+		if q.Mul(&f, &f).Gt(&rem) {
+			break
+		}
+		e := uint(0)
+		for r.Mod(&rem, &f).IsZero() {
+			rem.Div(&rem, &f)
+			e++
+		}
+		if e > 0 {
+			res = append(res, PrimePower{P: f, E: e})
+		}
+	}
+	if !rem.Eq(&Int{1}) {
+		res = append(res, PrimePower{P: rem, E: 1})
+	}
+	return res
+}
+
+// maxRootLogPrime bounds the primes q for which rootPrimePower takes
+// discrete logarithms in the subgroup of order q, keeping the baby-step
+// table of DiscreteLog below 2**16 entries.
+const maxRootLogPrime = 1 << 32
+
+// rootPrimePower sets z to a q**e-th root of a, for the prime power f =
+// q**e dividing p-1, where p is the prime in mod, and returns whether one
+// was found. a must be nonzero.
+func (z *Int) rootPrimePower(a *Int, f *PrimePower, mod *Modulus) bool {
+	p := &mod.m
+	q := &f.P
+	// p-1 = q**s * t, with t coprime to q.
+	var t, qs, r Int
+	t.SubUint64(p, 1)
+	qPow := []Int{{1}} // qPow[i] = q**i
+	for r.Mod(&t, q).IsZero() {
+		t.Div(&t, q)
+		var next Int
+		qPow = append(qPow, *next.Mul(&qPow[len(qPow)-1], q))
+	}
+	s := len(qPow) - 1
+	if uint(s) < f.E {
+		return false
+	}
+	qs = qPow[f.E] // the root degree q**e
+
+	// A first guess r = a**alpha, with alpha = (q**e)**-1 mod t, is off by
+	// the factor err = r**(q**e) / a, which lies in the q-Sylow subgroup.
+	var alpha, aInv, errF Int
+	alpha.ModInverse(&qs, &t)
+	mod.ExpMod(&r, a, &alpha)
+	aInv.InvModPrime(a, p)
+	mod.ExpMod(&errF, &r, &qs)
+	mod.MulMod(&errF, &errF, &aInv)
+	if errF.Eq(&Int{1}) {
+		z.Set(&r)
+		return true
+	}
+
+	// g = rho**t generates the q-Sylow subgroup, for a q-th non-residue rho.
+	var rho, c, g, gInv, h, pm1q Int
+	pm1q.SubUint64(p, 1)
+	pm1q.Div(&pm1q, q)
+	for rho.SetUint64(2); ; rho.AddUint64(&rho, 1) {
+		if rho.GtUint64(1000) || !rho.Lt(p) {
+			return false
+		}
+		if !mod.ExpMod(&c, &rho, &pm1q).Eq(&Int{1}) {
+			break
+		}
+	}
+	mod.ExpMod(&g, &rho, &t)
+	gInv.InvModPrime(&g, p)
+	mod.ExpMod(&h, &g, &qPow[s-1]) // of order q
+
+	// Find L = log_g(err) digit by digit (Pohlig-Hellman).
+	var L, step Int
+	for i := 0; i < s; i++ {
+		mod.ExpMod(&c, &gInv, &L)
+		mod.MulMod(&c, &c, &errF)
+		mod.ExpMod(&c, &c, &qPow[s-1-i])
+		if c.Eq(&Int{1}) {
+			continue
+		}
+		// The baby-step table of DiscreteLog has sqrt(q) entries.
+		if !q.LtUint64(maxRootLogPrime) {
+			return false
+		}
+		digit, ok := DiscreteLog(&h, &c, p, q.Uint64()-1)
+		if !ok {
+			return false
+		}
+		L.Add(&L, step.Mul(step.SetUint64(digit), &qPow[i]))
+	}
+	// err = g**L is a q**e-th power iff q**e divides L, and then
+	// r * g**(-L / q**e) is a root.
+	var quo, rem Int
+	if !rem.Mod(&L, &qs).IsZero() {
+		return false
+	}
+	quo.Div(&L, &qs)
+	mod.ExpMod(&c, &gInv, &quo)
+	mod.MulMod(z, &r, &c)
+	return true
+}
+
+// Jacobi returns the Jacobi symbol (a/n), which is 0, 1 or -1, for odd n.
+// If n is even, Jacobi returns 0 (OBS: differs from the big.Int, which panics)
+func Jacobi(a, n *Int) int {
+	if n[0]&1 == 0 {
+		return 0
+	}
+	var x, y, t Int
+	y = *n
+	x.Mod(a, &y)
+	j := 1
+	// Binary algorithm: strip factors of two from x using (2/y), then swap
+	// using quadratic reciprocity and reduce.
+	for !x.IsZero() {
+		if y[3]|y[2]|y[1] == 0 {
+			return j * jacobi64(x[0], y[0])
+		}
+		tz := uint(x.TrailingZeros())
+		x.Rsh(&x, tz)
+		if tz&1 == 1 && (y[0]&7 == 3 || y[0]&7 == 5) {
+			j = -j
+		}
+		if x[0]&3 == 3 && y[0]&3 == 3 {
+			j = -j
+		}
+		t.Mod(&y, &x)
+		x, y = t, x
+	}
+	if !y.Eq(&Int{1}) {
+		return 0
+	}
+	return j
+}
+
+// Kronecker returns the Kronecker symbol (a/n), which extends the Jacobi
+// symbol to even n: (a/2) is 0 for even a, 1 for a = 1 or 7 mod 8 and -1
+// for a = 3 or 5 mod 8, and (a/0) is 1 for a = 1 and 0 otherwise. Both a
+// and n are unsigned; see SKronecker for signed arguments.
+func Kronecker(a, n *Int) int {
+	return kronecker(a, n, false)
+}
+
+// SKronecker interprets a and n as two's complement signed integers, and
+// returns the Kronecker symbol (a/n), with (a/-1) = -1 for negative a and 1
+// otherwise.
+func SKronecker(a, n *Int) int {
+	var absA, absN Int
+	absA.Abs(a)
+	absN.Abs(n)
+	k := kronecker(&absA, &absN, a.Sign() < 0)
+	if n.Sign() < 0 && a.Sign() < 0 {
+		k = -k
+	}
+	return k
+}
+
+// kronecker returns the Kronecker symbol (a/n), or (-a/n) if neg is set.
+func kronecker(a, n *Int, neg bool) int {
+	if n.IsZero() {
+		if a.Eq(&Int{1}) {
+			return 1
+		}
+		return 0
+	}
+	k := 1
+	var odd Int
+	odd.Set(n)
+	if n[0]&1 == 0 {
+		if a[0]&1 == 0 {
+			return 0
+		}
+		tz := uint(n.TrailingZeros())
+		odd.Rsh(n, tz)
+		// The residue of -a mod 8 is that of a, negated.
+		r := a[0] & 7
+		if neg {
+			r = -r & 7
+		}
+		if tz&1 == 1 && (r == 3 || r == 5) {
+			k = -k
+		}
+	}
+	if !neg {
+		return k * Jacobi(a, &odd)
+	}
+	var r Int
+	return k * Jacobi(r.NegMod(a, &odd), &odd)
+}
+
+// jacobi64 returns the Jacobi symbol (a/n) for odd n.
+func jacobi64(a, n uint64) int {
+	a %= n
+	j := 1
+	for a != 0 {
+		tz := bits.TrailingZeros64(a)
+		a >>= uint(tz)
+		if tz&1 == 1 && (n&7 == 3 || n&7 == 5) {
+			j = -j
+		}
+		if a&3 == 3 && n&3 == 3 {
+			j = -j
+		}
+		a, n = n%a, a
+	}
+	if n != 1 {
+		return 0
+	}
+	return j
+}
+
+// Legendre returns the Legendre symbol (a/p) for an odd prime p: 0 if p
+// divides a, 1 if a is a nonzero square modulo p, and -1 otherwise. It is
+// computed as the Jacobi symbol, so the result for composite p is the
+// Jacobi symbol, and not an indication of residuosity.
+// If p is even, Legendre returns 0.
+func Legendre(a, p *Int) int {
+	return Jacobi(a, p)
+}
+
+// Totient sets z to Euler's totient phi(n) = prod p**(e-1) * (p-1) of the
+// number n whose factorization is given, and returns z.
+func (z *Int) Totient(factors []PrimePower) *Int {
+	res := Int{1}
+	var t Int
+	for i := range factors {
+		if factors[i].E == 0 {
+			continue
+		}
+		res.Mul(&res, t.SubUint64(&factors[i].P, 1))
+		for j := uint(1); j < factors[i].E; j++ {
+			res.Mul(&res, &factors[i].P)
+		}
+	}
+	return z.Set(&res)
+}
+
+// Carmichael sets z to the Carmichael function lambda(n), the exponent of
+// the multiplicative group modulo n, of the number n whose factorization is
+// given, and returns z.
+// It is the least common multiple of lambda(p**e), which equals phi(p**e)
+// except for powers of two from 8 on, where it is 2**(e-2).
+func (z *Int) Carmichael(factors []PrimePower) *Int {
+	res := Int{1}
+	var l, g Int
+	for i := range factors {
+		f := factors[i]
+		if f.E == 0 {
+			continue
+		}
+		if f.P.Eq(&Int{2}) && f.E >= 3 {
+			f.E--
+		}
+		l.Totient([]PrimePower{f})
+		// lcm(res, l) = res / gcd(res, l) * l
+		g.GCD(&res, &l)
+		res.Div(&res, &g)
+		res.Mul(&res, &l)
+	}
+	return z.Set(&res)
+}
+
+// IsQRBatch reports, for each x in xs, whether x is a square modulo the odd
+// prime p (zero counts as a square). It applies Euler's criterion
+// x**((p-1)/2) = 1, sharing one addition chain for the exponent and one
+// Modulus for p across the whole batch.
+func IsQRBatch(xs []Int, p *Int) []bool {
+	if instrumented {
+		defer region("IsQRBatch")()
+	}
+	res := make([]bool, len(xs))
+	if p.IsZero() {
+		return res
+	}
+	var e Int
+	e.SubUint64(p, 1)
+	e.Rsh(&e, 1)
+	chain := NewAddChain(&e)
+	mod := fixedModulus(p)
+	if mod == nil {
+		mod = NewModulus(p)
+	}
+	mul := func(z, x, y *Int) { mod.MulMod(z, x, y) }
+	var one, t Int
+	mod.Reduce(&one, &Int{1})
+	for i := range xs {
+		if mod.Reduce(&t, &xs[i]).IsZero() {
+			res[i] = true
+			continue
+		}
+		res[i] = chain.apply(&t, &t, &one, mul).Eq(&one)
+	}
+	return res
+}
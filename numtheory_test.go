@@ -0,0 +1,665 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// lucasRef computes U_n(P, Q) and V_n(P, Q) mod m by the plain recurrence.
+func lucasRef(n int, p, q, m *big.Int) (*big.Int, *big.Int) {
+	u0, u1 := big.NewInt(0), big.NewInt(1)
+	v0, v1 := big.NewInt(2), new(big.Int).Set(p)
+	for i := 0; i < n; i++ {
+		u2 := new(big.Int).Mul(p, u1)
+		u2.Sub(u2, new(big.Int).Mul(q, u0))
+		v2 := new(big.Int).Mul(p, v1)
+		v2.Sub(v2, new(big.Int).Mul(q, v0))
+		u0, u1 = u1, u2
+		v0, v1 = v1, v2
+	}
+	return u0.Mod(u0, m), v0.Mod(v0, m)
+}
+
+func mustHex(s string) *Int {
+	z, err := FromHex(s)
+	if err != nil {
+		panic(err)
+	}
+	return z
+}
+
+// secp256k1PMinus1 is the factorization of p-1 for the secp256k1 field prime.
+var secp256k1PMinus1 = []PrimePower{
+	{Int{2}, 1}, {Int{3}, 1}, {Int{7}, 1}, {Int{0x3481}, 1},
+	{*mustHex("0x1db8260e5e3b460a46a0088fccf6a3a5936d75d89a776d4c0da4f338aafb"), 1},
+}
+
+func TestLucasMod(t *testing.T) {
+	mods := []int64{1, 2, 10, 97, 1000003, 1 << 40}
+	params := [][2]int64{{1, -1}, {3, 2}, {1, 2}, {5, -3}, {0, 0}}
+	for _, mv := range mods {
+		m := big.NewInt(mv)
+		for _, pq := range params {
+			p, q := big.NewInt(pq[0]), big.NewInt(pq[1])
+			pf, _ := FromBig(new(big.Int).Mod(p, m))
+			qf, _ := FromBig(new(big.Int).Mod(q, m))
+			mf, _ := FromBig(m)
+			for n := 0; n < 70; n++ {
+				wantU, wantV := lucasRef(n, p, q, m)
+				u, v := LucasMod(NewInt(uint64(n)), pf, qf, mf)
+				requireEq(t, wantU, &u, "LucasMod U")
+				requireEq(t, wantV, &v, "LucasMod V")
+			}
+		}
+	}
+}
+
+func TestLucasModIdentity(t *testing.T) {
+	// V_n^2 - D*U_n^2 = 4*Q^n, with D = P^2 - 4Q.
+	m, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	mf, _ := FromBig(m)
+	for i := 0; i < 100; i++ {
+		bn, n, _ := randNums()
+		bp, p, _ := randNums()
+		bq, q, _ := randNums()
+		bp.Mod(bp, m)
+		bq.Mod(bq, m)
+		p.Mod(p, mf)
+		q.Mod(q, mf)
+		u, v := LucasMod(n, p, q, mf)
+		d := new(big.Int).Sub(new(big.Int).Mul(bp, bp), new(big.Int).Lsh(bq, 2))
+		lhs := new(big.Int).Mul(v.ToBig(), v.ToBig())
+		lhs.Sub(lhs, new(big.Int).Mul(d, new(big.Int).Mul(u.ToBig(), u.ToBig())))
+		lhs.Mod(lhs, m)
+		rhs := new(big.Int).Exp(bq, bn, m)
+		rhs.Lsh(rhs, 2).Mod(rhs, m)
+		if lhs.Cmp(rhs) != 0 {
+			t.Fatalf("identity failed for n=%x p=%x q=%x", n, p, q)
+		}
+	}
+}
+
+func TestFibonacciMod(t *testing.T) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	m := big.NewInt(1000000007)
+	mf, _ := FromBig(m)
+	for n := uint64(0); n < 300; n++ {
+		requireEq(t, new(big.Int).Mod(a, m), new(Int).FibonacciMod(NewInt(n), mf), "FibonacciMod")
+		a, b = b, a.Add(a, b)
+	}
+	// The Pisano period of 10 is 60.
+	n, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	r := new(Int).Mod(n, NewInt(60))
+	if got, want := new(Int).FibonacciMod(n, NewInt(10)), new(Int).FibonacciMod(r, NewInt(10)); !got.Eq(want) {
+		t.Errorf("got %d, want %d", got, want)
+	}
+	if got := new(Int).FibonacciMod(NewInt(10), new(Int)); !got.IsZero() {
+		t.Errorf("expected 0 for zero modulus, got %x", got)
+	}
+}
+
+func TestFactorialMod(t *testing.T) {
+	for _, mv := range []int64{1, 2, 7, 1000000007, 1 << 62} {
+		m := big.NewInt(mv)
+		mf, _ := FromBig(m)
+		f := big.NewInt(1)
+		for n := int64(0); n < 50; n++ {
+			if n > 0 {
+				f.Mul(f, big.NewInt(n))
+			}
+			requireEq(t, new(big.Int).Mod(f, m), new(Int).FactorialMod(uint64(n), mf), "FactorialMod")
+		}
+	}
+	if got := new(Int).FactorialMod(5, new(Int)); !got.IsZero() {
+		t.Errorf("expected 0 for zero modulus, got %x", got)
+	}
+}
+
+func TestBinomialMod(t *testing.T) {
+	p256, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	for _, p := range []*big.Int{big.NewInt(2), big.NewInt(7), big.NewInt(13), big.NewInt(1000000007), p256} {
+		pf, _ := FromBig(p)
+		for n := uint64(0); n < 60; n++ {
+			for k := uint64(0); k <= n+1; k++ {
+				want := new(big.Int).Binomial(int64(n), int64(k))
+				if k > n {
+					want.SetUint64(0)
+				}
+				requireEq(t, want.Mod(want, p), new(Int).BinomialMod(n, k, pf), "BinomialMod")
+			}
+		}
+	}
+	// Lucas' theorem with a large n.
+	p := big.NewInt(10007)
+	pf, _ := FromBig(p)
+	n, k := uint64(123456789012), uint64(9876543)
+	want := big.NewInt(1)
+	for nn, kk := n, k; nn > 0; nn, kk = nn/10007, kk/10007 {
+		c := new(big.Int).Binomial(int64(nn%10007), int64(kk%10007))
+		if kk%10007 > nn%10007 {
+			c.SetUint64(0)
+		}
+		want.Mul(want, c).Mod(want, p)
+	}
+	requireEq(t, want, new(Int).BinomialMod(n, k, pf), "BinomialMod Lucas")
+}
+
+func TestFactorialTable(t *testing.T) {
+	p, _ := new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+	pf, _ := FromBig(p)
+	table := NewFactorialTable(100, pf)
+	var z Int
+	for n := uint64(0); n <= 100; n++ {
+		requireEq(t, new(big.Int).Mod(new(big.Int).MulRange(1, int64(n)), p), table.Factorial(&z, n), "Factorial")
+		for k := uint64(0); k <= n+1; k++ {
+			want := new(big.Int).Binomial(int64(n), int64(k))
+			if k > n {
+				want.SetUint64(0)
+			}
+			requireEq(t, want.Mod(want, p), table.Binomial(&z, n, k), "Binomial")
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for table not smaller than modulus")
+		}
+	}()
+	NewFactorialTable(7, NewInt(7))
+}
+
+func TestDiscreteLog(t *testing.T) {
+	p, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	g := NewInt(3)
+	for _, x := range []uint64{0, 1, 2, 1000, 123456, 999999, 1 << 20} {
+		target := new(Int).ExpMod(g, NewInt(x), p)
+		got, ok := DiscreteLog(g, target, p, 1<<20)
+		if !ok || got != x {
+			t.Errorf("DiscreteLog(%d): got %d, %v", x, got, ok)
+		}
+	}
+	// Out of bound.
+	target := new(Int).ExpMod(g, NewInt(5000), p)
+	if x, ok := DiscreteLog(g, target, p, 4999); ok {
+		t.Errorf("expected no solution, got %d", x)
+	}
+	// Smallest solution in a small group: 2 has order 10 mod 11.
+	if x, ok := DiscreteLog(NewInt(2), NewInt(9), NewInt(11), 100); !ok || x != 6 {
+		t.Errorf("got %d, %v, want 6", x, ok)
+	}
+	if _, ok := DiscreteLog(g, g, new(Int), 10); ok {
+		t.Error("expected no solution for zero modulus")
+	}
+}
+
+// orderRef computes the multiplicative order of a mod m by brute force.
+func orderRef(a, m uint64) uint64 {
+	x := a % m
+	for k := uint64(1); k <= m; k++ {
+		if x == 1%m {
+			return k
+		}
+		x = x * a % m
+	}
+	return 0
+}
+
+func TestOrder(t *testing.T) {
+	// phi(1000) = 400 = 2^4 * 5^2, phi(1009) = 1008 = 2^4 * 3^2 * 7.
+	cases := []struct {
+		m   uint64
+		phi []PrimePower
+	}{
+		{1000, []PrimePower{{Int{2}, 4}, {Int{5}, 2}}},
+		{1009, []PrimePower{{Int{2}, 4}, {Int{3}, 2}, {Int{7}, 1}}},
+	}
+	for _, tc := range cases {
+		for a := uint64(0); a < tc.m; a++ {
+			want := orderRef(a, tc.m)
+			got := new(Int).Order(NewInt(a), NewInt(tc.m), tc.phi)
+			if !got.Eq(NewInt(want)) {
+				t.Fatalf("Order(%d, %d): got %d, want %d", a, tc.m, got, want)
+			}
+		}
+	}
+	// Check the defining property on a large prime modulus.
+	p, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	pb := p.ToBig()
+	for _, a := range []uint64{2, 3, 7, 1 << 40} {
+		ord := new(Int).Order(NewInt(a), p, secp256k1PMinus1)
+		ob := ord.ToBig()
+		ab := new(big.Int).SetUint64(a)
+		if new(big.Int).Exp(ab, ob, pb).Cmp(big.NewInt(1)) != 0 {
+			t.Fatalf("%d^%x != 1", a, ord)
+		}
+		for _, f := range secp256k1PMinus1 {
+			q := f.P.ToBig()
+			if new(big.Int).Mod(ob, q).Sign() != 0 {
+				continue
+			}
+			if new(big.Int).Exp(ab, new(big.Int).Div(ob, q), pb).Cmp(big.NewInt(1)) == 0 {
+				t.Fatalf("order of %d is not minimal: %x", a, ord)
+			}
+		}
+	}
+	if got := new(Int).Order(p, p, secp256k1PMinus1); !got.IsZero() {
+		t.Errorf("expected 0 for a non-unit, got %x", got)
+	}
+}
+
+func TestPrimitiveRoot(t *testing.T) {
+	// The smallest primitive root of 1009 is 11.
+	phi1009 := []PrimePower{{Int{2}, 4}, {Int{3}, 2}, {Int{7}, 1}}
+	if got := new(Int).PrimitiveRoot(NewInt(1009), phi1009); !got.Eq(NewInt(11)) {
+		t.Errorf("got %d, want 11", got)
+	}
+	if got := new(Int).Order(NewInt(11), NewInt(1009), phi1009); !got.Eq(NewInt(1008)) {
+		t.Errorf("order of 11 mod 1009: got %d", got)
+	}
+	if got := new(Int).PrimitiveRoot(NewInt(2), nil); !got.Eq(NewInt(1)) {
+		t.Errorf("got %d, want 1", got)
+	}
+	if got := new(Int).PrimitiveRoot(NewInt(1), nil); !got.IsZero() {
+		t.Errorf("got %d, want 0", got)
+	}
+	p, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	g := new(Int).PrimitiveRoot(p, secp256k1PMinus1)
+	pm1 := new(Int).SubUint64(p, 1)
+	if got := new(Int).Order(g, p, secp256k1PMinus1); !got.Eq(pm1) {
+		t.Errorf("order of %d: got %x, want %x", g, got, pm1)
+	}
+	for x := NewInt(2); x.Lt(g); x.AddUint64(x, 1) {
+		if new(Int).Order(x, p, secp256k1PMinus1).Eq(pm1) {
+			t.Errorf("%d is a smaller generator than %d", x, g)
+		}
+	}
+}
+
+func TestTotientCarmichael(t *testing.T) {
+	// factorize returns the factorization of a small n by trial division.
+	factorize := func(n uint64) []PrimePower {
+		var f []PrimePower
+		for p := uint64(2); p*p <= n; p++ {
+			var e uint
+			for n%p == 0 {
+				n /= p
+				e++
+			}
+			if e > 0 {
+				f = append(f, PrimePower{Int{p}, e})
+			}
+		}
+		if n > 1 {
+			f = append(f, PrimePower{Int{n}, 1})
+		}
+		return f
+	}
+	gcd := func(a, b uint64) uint64 {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	for n := uint64(1); n < 500; n++ {
+		var phi uint64
+		for a := uint64(1); a <= n; a++ {
+			if gcd(a, n) == 1 {
+				phi++
+			}
+		}
+		// lambda(n) is the largest order of any unit.
+		var lambda uint64 = 1
+		for a := uint64(1); a < n; a++ {
+			if gcd(a, n) == 1 {
+				if o := orderRef(a, n); o > lambda {
+					lambda = o
+				}
+			}
+		}
+		f := factorize(n)
+		if got := new(Int).Totient(f); !got.Eq(NewInt(phi)) {
+			t.Fatalf("Totient(%d): got %d, want %d", n, got, phi)
+		}
+		if got := new(Int).Carmichael(f); !got.Eq(NewInt(lambda)) {
+			t.Fatalf("Carmichael(%d): got %d, want %d", n, got, lambda)
+		}
+	}
+	// Large prime: phi(p) = lambda(p) = p-1.
+	p, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	pm1 := new(Int).SubUint64(p, 1)
+	if got := new(Int).Totient([]PrimePower{{*p, 1}}); !got.Eq(pm1) {
+		t.Errorf("got %x", got)
+	}
+	if got := new(Int).Carmichael([]PrimePower{{*p, 1}, {Int{2}, 1}}); !got.Eq(pm1) {
+		t.Errorf("got %x", got)
+	}
+}
+
+func TestIsQRBatch(t *testing.T) {
+	for _, pv := range []uint64{3, 7, 1009} {
+		squares := make(map[uint64]bool)
+		for a := uint64(0); a < pv; a++ {
+			squares[a*a%pv] = true
+		}
+		xs := make([]Int, 2*pv)
+		for i := range xs {
+			xs[i].SetUint64(uint64(i))
+		}
+		for i, got := range IsQRBatch(xs, NewInt(pv)) {
+			if want := squares[uint64(i)%pv]; got != want {
+				t.Fatalf("IsQRBatch(%d mod %d): got %v, want %v", i, pv, got, want)
+			}
+		}
+	}
+	p, _ := FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	xs := make([]Int, 50)
+	for i := range xs {
+		_, f, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		xs[i] = *f
+	}
+	pb := p.ToBig()
+	for i, got := range IsQRBatch(xs, p) {
+		want := big.Jacobi(new(big.Int).Mod(xs[i].ToBig(), pb), pb) >= 0
+		if got != want {
+			t.Errorf("IsQRBatch(%x): got %v, want %v", &xs[i], got, want)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	check := func(x, m *Int) {
+		t.Helper()
+		got, ok := new(Int).ModInverse(x, m)
+		want := new(big.Int).ModInverse(x.ToBig(), m.ToBig())
+		if want == nil {
+			if ok || !got.IsZero() {
+				t.Fatalf("ModInverse(%x, %x): got %x, %v, want 0, false", x, m, got, ok)
+			}
+			return
+		}
+		if !ok {
+			t.Fatalf("ModInverse(%x, %x): got no inverse, want %x", x, m, want)
+		}
+		requireEq(t, want, got, fmt.Sprintf("ModInverse(%x, %x)", x, m))
+	}
+	for m := uint64(1); m < 60; m++ {
+		for x := uint64(0); x < 2*m; x++ {
+			check(NewInt(x), NewInt(m))
+		}
+	}
+	max := new(Int).SetAllOne()
+	p := mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	for i := 0; i < 500; i++ {
+		_, x, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, m, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range []*Int{m, p, max} {
+			if !m.IsZero() {
+				check(x, m)
+			}
+		}
+	}
+	if got, ok := new(Int).ModInverse(NewInt(3), new(Int)); ok || !got.IsZero() {
+		t.Errorf("ModInverse with zero modulus: got %x, %v, want 0, false", got, ok)
+	}
+}
+
+func TestModSqrt(t *testing.T) {
+	for _, p := range []*Int{
+		mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		mustHex("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+		mustHex("0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"),
+		NewInt(3), NewInt(5), NewInt(13), NewInt(17), NewInt(97), NewInt(65537),
+	} {
+		bp := p.ToBig()
+		check := func(x *Int) {
+			t.Helper()
+			got, ok := new(Int).ModSqrt(x, p)
+			want := new(big.Int).ModSqrt(new(big.Int).Mod(x.ToBig(), bp), bp)
+			if ok != (want != nil) {
+				t.Fatalf("ModSqrt(%x, %x): got ok %v, want %v", x, p, ok, want != nil)
+			}
+			if !ok {
+				if !got.IsZero() {
+					t.Fatalf("ModSqrt(%x, %x): got %x for a non-square", x, p, got)
+				}
+				return
+			}
+			var sq Int
+			if !sq.MulMod(got, got, p).Eq(new(Int).Mod(x, p)) {
+				t.Fatalf("ModSqrt(%x, %x): got %x, whose square is %x", x, p, got, &sq)
+			}
+		}
+		for i := uint64(0); i < 40; i++ {
+			check(NewInt(i))
+		}
+		for i := 0; i < 100; i++ {
+			_, x, err := randHighNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			check(x)
+			// x**2 is always a square.
+			check(x.MulMod(x, x, p))
+		}
+	}
+	for _, p := range []*Int{new(Int), NewInt(8)} {
+		if got, ok := new(Int).ModSqrt(NewInt(4), p); ok || !got.IsZero() {
+			t.Errorf("ModSqrt(4, %v): got %v, %v, want 0, false", p, got, ok)
+		}
+	}
+}
+
+func TestJacobi(t *testing.T) {
+	for n := uint64(1); n < 200; n += 2 {
+		for a := uint64(0); a < 2*n; a++ {
+			want := big.Jacobi(new(big.Int).SetUint64(a), new(big.Int).SetUint64(n))
+			if got := Jacobi(NewInt(a), NewInt(n)); got != want {
+				t.Fatalf("Jacobi(%d, %d): got %d, want %d", a, n, got, want)
+			}
+		}
+	}
+	for i := 0; i < 2000; i++ {
+		_, a, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, n, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			n[3] |= 1 << 63
+		}
+		n[0] |= 1
+		if got, want := Jacobi(a, n), big.Jacobi(a.ToBig(), n.ToBig()); got != want {
+			t.Fatalf("Jacobi(%x, %x): got %d, want %d", a, n, got, want)
+		}
+	}
+	if got := Jacobi(NewInt(3), NewInt(10)); got != 0 {
+		t.Errorf("Jacobi with even n: got %d, want 0", got)
+	}
+	p := mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	for i := uint64(0); i < 50; i++ {
+		x := NewInt(i)
+		want := 0
+		if i != 0 {
+			want = -1
+			if _, ok := new(Int).ModSqrt(x, p); ok {
+				want = 1
+			}
+		}
+		if got := Legendre(x, p); got != want {
+			t.Errorf("Legendre(%d, p): got %d, want %d", i, got, want)
+		}
+	}
+}
+
+// bigKronecker is the Kronecker symbol (a/n) for signed a and n, from its
+// definition.
+func bigKronecker(a, n *big.Int) int {
+	if n.Sign() == 0 {
+		if a.CmpAbs(big.NewInt(1)) == 0 {
+			return 1
+		}
+		return 0
+	}
+	k := 1
+	n = new(big.Int).Set(n)
+	if n.Sign() < 0 {
+		n.Neg(n)
+		if a.Sign() < 0 {
+			k = -k
+		}
+	}
+	for n.Bit(0) == 0 {
+		if a.Bit(0) == 0 {
+			return 0
+		}
+		if r := new(big.Int).Mod(a, big.NewInt(8)).Int64(); r == 3 || r == 5 {
+			k = -k
+		}
+		n.Rsh(n, 1)
+	}
+	return k * big.Jacobi(a, n)
+}
+
+func TestKronecker(t *testing.T) {
+	for n := int64(-40); n < 40; n++ {
+		for a := int64(-40); a < 40; a++ {
+			ba, bn := big.NewInt(a), big.NewInt(n)
+			want := bigKronecker(ba, bn)
+			sa, _ := FromBig(new(big.Int).And(ba, new(big.Int).Sub(bigtt256, big.NewInt(1))))
+			sn, _ := FromBig(new(big.Int).And(bn, new(big.Int).Sub(bigtt256, big.NewInt(1))))
+			if got := SKronecker(sa, sn); got != want {
+				t.Fatalf("SKronecker(%d, %d): got %d, want %d", a, n, got, want)
+			}
+			if a >= 0 && n >= 0 {
+				if got := Kronecker(sa, sn); got != want {
+					t.Fatalf("Kronecker(%d, %d): got %d, want %d", a, n, got, want)
+				}
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		ba, a, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bn, n, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			// Make n even, with a varying power of two.
+			n.Lsh(n, uint(i%7+1))
+			bn = n.ToBig()
+		}
+		if got, want := Kronecker(a, n), bigKronecker(ba, bn); got != want {
+			t.Fatalf("Kronecker(%x, %x): got %d, want %d", a, n, got, want)
+		}
+		sa, sn := a.ToBig(), n.ToBig()
+		if a.Sign() < 0 {
+			sa.Sub(sa, bigtt256)
+		}
+		if n.Sign() < 0 {
+			sn.Sub(sn, bigtt256)
+		}
+		if got, want := SKronecker(a, n), bigKronecker(sa, sn); got != want {
+			t.Fatalf("SKronecker(%x, %x): got %d, want %d", a, n, got, want)
+		}
+	}
+}
+
+func TestModRoot(t *testing.T) {
+	// Compare with brute force for small primes, whose p-1 have repeated
+	// factors.
+	for _, p := range []uint64{3, 13, 97, 109, 193, 257} {
+		roots := make(map[[2]uint64]bool)
+		for n := uint64(0); n < 20; n++ {
+			for r := uint64(0); r < p; r++ {
+				y := new(Int).ExpMod(NewInt(r), NewInt(n), NewInt(p))
+				roots[[2]uint64{n, y.Uint64()}] = true
+			}
+		}
+		for n := uint64(0); n < 20; n++ {
+			for x := uint64(0); x < p; x++ {
+				got, ok := new(Int).ModRoot(NewInt(x), NewInt(n), NewInt(p))
+				if want := roots[[2]uint64{n, x}]; ok != want {
+					t.Fatalf("ModRoot(%d, %d, %d): got ok = %v, want %v", x, n, p, ok, want)
+				}
+				if !ok {
+					if !got.IsZero() {
+						t.Fatalf("ModRoot(%d, %d, %d): got %v without a root", x, n, p, got)
+					}
+					continue
+				}
+				if y := new(Int).ExpMod(got, NewInt(n), NewInt(p)); y.Uint64() != x%p && !(n == 0 && x == 1) {
+					t.Fatalf("ModRoot(%d, %d, %d) = %v, but %v**%d = %v", x, n, p, got, got, n, y)
+				}
+			}
+		}
+	}
+	// Large primes: powers have roots, which ModRoot must find.
+	for _, p := range []*Int{
+		mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47"),
+		mustHex("0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"),
+		mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		mustHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
+	} {
+		for _, n := range []*Int{NewInt(1), NewInt(2), NewInt(3), NewInt(5), NewInt(6), NewInt(9), NewInt(16), NewInt(1 << 28), NewInt(13441), mustHex("0x10000000000000001")} {
+			for i := 0; i < 3; i++ {
+				_, y, err := randHighNums()
+				if err != nil {
+					t.Fatal(err)
+				}
+				x := new(Int).ExpMod(y, n, p)
+				got, ok := new(Int).ModRoot(x, n, p)
+				if !ok {
+					t.Fatalf("ModRoot(%x, %v, %x): no root found", x, n, p)
+				}
+				if r := new(Int).ExpMod(got, n, p); !r.Eq(x) {
+					t.Fatalf("ModRoot(%x, %v, %x) = %x, but its power is %x", x, n, p, got, r)
+				}
+			}
+		}
+	}
+	// p = 138*q**2 + 1, for the prime q = 2**61 - 1: a q-th root needs a
+	// discrete logarithm in the subgroup of order q, which is too large to
+	// take. ModRoot must give up promptly rather than build a table of 2**31
+	// entries, and any root it does return must be valid.
+	p := mustHex("0x227ffffffffffffdd800000000000008b")
+	q := NewInt(1<<61 - 1)
+	for i := 0; i < 4; i++ {
+		_, y, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		x := new(Int).ExpMod(y, q, p)
+		got, ok := new(Int).ModRoot(x, q, p)
+		if !ok {
+			if !got.IsZero() {
+				t.Fatalf("ModRoot(%x, %v, %x): got %x without a root", x, q, p, got)
+			}
+			continue
+		}
+		if r := new(Int).ExpMod(got, q, p); !r.Eq(x) {
+			t.Fatalf("ModRoot(%x, %v, %x) = %x, but its power is %x", x, q, p, got, r)
+		}
+	}
+	if got, ok := new(Int).ModRoot(NewInt(4), NewInt(2), NewInt(10)); ok || !got.IsZero() {
+		t.Errorf("ModRoot with even p: got %v, %v, want 0, false", got, ok)
+	}
+}
@@ -0,0 +1,252 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrDuplicateX is returned by Interpolate and EvalLagrange when two of the
+// points have the same x coordinate modulo m.
+var ErrDuplicateX = errors.New("interpolation points with equal x coordinates")
+
+// Point is a point (X, Y) of a polynomial over a prime field.
+type Point struct {
+	X, Y Int
+}
+
+// reduce512 returns x mod m, for a non-zero m.
+func reduce512(x *[8]uint64, m *Int) Int {
+	var r Int
+	if x[4]|x[5]|x[6]|x[7] == 0 {
+		copy(r[:], x[:4])
+		return *r.Mod(&r, m)
+	}
+	var quot [8]uint64
+	return udivrem(quot[:], x[:], m)
+}
+
+// mulAddMod returns (x*y + c) mod m, with a single reduction, for a
+// non-zero m. The intermediate cannot overflow 512 bits, since
+// (2**256-1)**2 + 2**256-1 < 2**512.
+func mulAddMod(x, y, c, m *Int) Int {
+	p := umulAdd(x, y, c)
+	return reduce512(&p, m)
+}
+
+// umulAdd returns the 512-bit x*y + c.
+func umulAdd(x, y, c *Int) [8]uint64 {
+	p := umul(x, y)
+	var carry uint64
+	p[0], carry = bits.Add64(p[0], c[0], 0)
+	p[1], carry = bits.Add64(p[1], c[1], carry)
+	p[2], carry = bits.Add64(p[2], c[2], carry)
+	p[3], carry = bits.Add64(p[3], c[3], carry)
+	for i := 4; i < 8 && carry != 0; i++ {
+		p[i], carry = bits.Add64(p[i], 0, carry)
+	}
+	return p
+}
+
+// EvalPolyMod sets z to the value of the polynomial with the given
+// coefficients at x, modulo m, and returns z. coeffs[i] is the coefficient
+// of x**i; the coefficients need not be reduced.
+// The polynomial is evaluated with Horner's rule, reducing once per
+// coefficient instead of after each multiplication and addition.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) EvalPolyMod(coeffs []Int, x, m *Int) *Int {
+	if m.IsZero() || len(coeffs) == 0 {
+		return z.Clear()
+	}
+	var xr, acc Int
+	xr.Mod(x, m)
+	acc.Mod(&coeffs[len(coeffs)-1], m)
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		acc = mulAddMod(&acc, &xr, &coeffs[i], m)
+	}
+	return z.Set(&acc)
+}
+
+// EvalPolyMod sets z to the value of the polynomial with the given
+// coefficients at x, modulo m, and returns z. See Int.EvalPolyMod.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (mod *Modulus) EvalPolyMod(z *Int, coeffs []Int, x *Int) *Int {
+	if mod.m.IsZero() || len(coeffs) == 0 {
+		return z.Clear()
+	}
+	var xr, acc Int
+	mod.Reduce(&xr, x)
+	mod.Reduce(&acc, &coeffs[len(coeffs)-1])
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		p := umulAdd(&acc, &xr, &coeffs[i])
+		acc = mod.reduce(&p)
+	}
+	return z.Set(&acc)
+}
+
+// EvalPolyModBatch returns the values of the polynomial with the given
+// coefficients at each of the points xs, modulo m, as EvalPolyMod would.
+// The reduction constants for m are computed once for the whole batch.
+// If m == 0, the values are 0 (OBS: differs from the big.Int)
+func EvalPolyModBatch(coeffs, xs []Int, m *Int) []Int {
+	if instrumented {
+		defer region("EvalPolyModBatch")()
+	}
+	res := make([]Int, len(xs))
+	mod := fixedModulus(m)
+	if mod == nil {
+		mod = new(Modulus).init(m)
+	}
+	for i := range xs {
+		mod.EvalPolyMod(&res[i], coeffs, &xs[i])
+	}
+	return res
+}
+
+// batchInvModPrime replaces each element of xs, which must be reduced, by
+// its inverse modulo the prime p, using Montgomery's trick to perform a
+// single modular inversion. It returns false, leaving xs unmodified, if any
+// element is zero.
+func batchInvModPrime(xs []Int, p *Int) bool {
+	if len(xs) == 0 {
+		return true
+	}
+	prefix := make([]Int, len(xs))
+	acc := Int{1}
+	for i := range xs {
+		if xs[i].IsZero() {
+			return false
+		}
+		prefix[i] = acc
+		acc.MulMod(&acc, &xs[i], p)
+	}
+	acc.InvModPrime(&acc, p)
+	for i := len(xs) - 1; i >= 0; i-- {
+		var inv Int
+		inv.MulMod(&acc, &prefix[i], p)
+		acc.MulMod(&acc, &xs[i], p)
+		xs[i] = inv
+	}
+	return true
+}
+
+// reducePoints returns a copy of points with the coordinates reduced
+// modulo m.
+func reducePoints(points []Point, m *Int) []Point {
+	res := make([]Point, len(points))
+	for i := range points {
+		res[i].X.Mod(&points[i].X, m)
+		res[i].Y.Mod(&points[i].Y, m)
+	}
+	return res
+}
+
+// lagrangeDenominators returns the inverses of the barycentric denominators
+// prod_{j != i} (x_i - x_j) modulo the prime m, or ErrDuplicateX.
+func lagrangeDenominators(points []Point, m *Int) ([]Int, error) {
+	den := make([]Int, len(points))
+	for i := range points {
+		den[i].Mod(&Int{1}, m)
+		for j := range points {
+			if i != j {
+				var d Int
+				d.subModReduced(&points[i].X, &points[j].X, m)
+				den[i].MulMod(&den[i], &d, m)
+			}
+		}
+	}
+	if !batchInvModPrime(den, m) {
+		return nil, ErrDuplicateX
+	}
+	return den, nil
+}
+
+// Interpolate returns the coefficients of the unique polynomial of degree
+// less than len(points) passing through the points, modulo the prime m.
+// The i-th coefficient is that of x**i, as for EvalPolyMod.
+// It returns ErrDuplicateX if two points have the same x coordinate modulo
+// m, and ErrDivByZero if m == 0. The result is only meaningful if m is
+// prime.
+func Interpolate(points []Point, m *Int) ([]Int, error) {
+	if m.IsZero() {
+		return nil, ErrDivByZero
+	}
+	pts := reducePoints(points, m)
+	den, err := lagrangeDenominators(pts, m)
+	if err != nil {
+		return nil, err
+	}
+	n := len(pts)
+	// master holds the coefficients of prod_j (X - x_j), of degree n.
+	master := make([]Int, n+1)
+	master[0].Mod(&Int{1}, m)
+	for j := range pts {
+		var negX Int
+		negX.subModReduced(&Int{}, &pts[j].X, m)
+		for k := j + 1; k > 0; k-- {
+			var t Int
+			t.MulMod(&master[k], &negX, m)
+			master[k].AddMod(&master[k-1], &t, m)
+		}
+		master[0].MulMod(&master[0], &negX, m)
+	}
+	coeffs := make([]Int, n)
+	basis := make([]Int, n)
+	for i := range pts {
+		// basis = master / (X - x_i), by synthetic division.
+		basis[n-1] = master[n]
+		for k := n - 1; k > 0; k-- {
+			var t Int
+			t.MulMod(&basis[k], &pts[i].X, m)
+			basis[k-1].AddMod(&master[k], &t, m)
+		}
+		var w Int
+		w.MulMod(&pts[i].Y, &den[i], m)
+		for k := range coeffs {
+			coeffs[k] = mulAddMod(&basis[k], &w, &coeffs[k], m)
+		}
+	}
+	return coeffs, nil
+}
+
+// EvalLagrange sets z to the value at x of the polynomial of degree less
+// than len(points) passing through the points, modulo the prime m, and
+// returns z. Unlike Interpolate, it does not compute the coefficients.
+// It returns ErrDuplicateX if two points have the same x coordinate modulo
+// m, and ErrDivByZero if m == 0; z is left unmodified in that case.
+func (z *Int) EvalLagrange(points []Point, x, m *Int) (*Int, error) {
+	if m.IsZero() {
+		return z, ErrDivByZero
+	}
+	pts := reducePoints(points, m)
+	den, err := lagrangeDenominators(pts, m)
+	if err != nil {
+		return z, err
+	}
+	var xr Int
+	xr.Mod(x, m)
+	// With l(x) = prod_j (x - x_j), the value is
+	// l(x) * sum_i y_i / (den_i * (x - x_i)).
+	diffs := make([]Int, len(pts))
+	for i := range pts {
+		diffs[i].subModReduced(&xr, &pts[i].X, m)
+		if diffs[i].IsZero() {
+			return z.Set(&pts[i].Y), nil
+		}
+	}
+	l := Int{1}
+	for i := range diffs {
+		l.MulMod(&l, &diffs[i], m)
+	}
+	batchInvModPrime(diffs, m)
+	var sum Int
+	for i := range pts {
+		var w Int
+		w.MulMod(&pts[i].Y, &den[i], m)
+		sum = mulAddMod(&w, &diffs[i], &sum, m)
+	}
+	return z.MulMod(&sum, &l, m), nil
+}
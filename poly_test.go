@@ -0,0 +1,189 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func bigEvalPoly(coeffs []*big.Int, x, m *big.Int) *big.Int {
+	res := new(big.Int)
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(res, x)
+		res.Add(res, coeffs[i])
+		res.Mod(res, m)
+	}
+	return res
+}
+
+func TestEvalPolyMod(t *testing.T) {
+	max := new(big.Int).Sub(bigtt256, big.NewInt(1))
+	mods := []*big.Int{big.NewInt(1), big.NewInt(97), new(big.Int).Lsh(big.NewInt(1), 128), max}
+	for i := 0; i < 10; i++ {
+		b, _, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Sign() != 0 {
+			mods = append(mods, b)
+		}
+	}
+	for _, bm := range mods {
+		m, _ := FromBig(bm)
+		for n := 0; n < 12; n++ {
+			coeffs := make([]Int, n)
+			bigCoeffs := make([]*big.Int, n)
+			for i := range coeffs {
+				b, f, err := randNums()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if i%3 == 0 {
+					b, f = max, new(Int).SetAllOne()
+				}
+				coeffs[i], bigCoeffs[i] = *f, b
+			}
+			bx, x, err := randNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := new(Int).EvalPolyMod(coeffs, x, m)
+			requireEq(t, bigEvalPoly(bigCoeffs, bx, bm), got, "EvalPolyMod")
+		}
+	}
+	coeffs := []Int{{1}, {2}}
+	if got := new(Int).EvalPolyMod(coeffs, NewInt(3), new(Int)); !got.IsZero() {
+		t.Errorf("expected 0 for zero modulus, got %x", got)
+	}
+}
+
+func TestEvalPolyModBatch(t *testing.T) {
+	for _, bm := range testModuli(t) {
+		m, _ := FromBig(bm)
+		for _, n := range []int{0, 1, 7} {
+			coeffs := make([]Int, n)
+			for i := range coeffs {
+				_, f, _ := randHighNums()
+				coeffs[i] = *f
+			}
+			xs := make([]Int, 5)
+			for i := range xs {
+				_, x, _ := randNums()
+				xs[i] = *x
+			}
+			for i, got := range EvalPolyModBatch(coeffs, xs, m) {
+				if want := new(Int).EvalPolyMod(coeffs, &xs[i], m); !got.Eq(want) {
+					t.Fatalf("EvalPolyModBatch(%x) at %x mod %x: got %x, want %x", coeffs, &xs[i], m, &got, want)
+				}
+			}
+		}
+	}
+	for _, got := range EvalPolyModBatch([]Int{{1}, {2}}, []Int{{3}}, new(Int)) {
+		if !got.IsZero() {
+			t.Errorf("expected 0 for zero modulus, got %x", &got)
+		}
+	}
+}
+
+func BenchmarkEvalPolyMod(b *testing.B) {
+	m, _ := FromHex("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+	x, _ := FromHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	coeffs := make([]Int, 64)
+	for i := range coeffs {
+		coeffs[i].SetUint64(uint64(i)).MulMod(&coeffs[i], x, m)
+	}
+	b.Run("EvalPolyMod", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.EvalPolyMod(coeffs, x, m)
+		}
+	})
+	b.Run("Modulus", func(b *testing.B) {
+		mod := NewModulus(m)
+		var z Int
+		for i := 0; i < b.N; i++ {
+			mod.EvalPolyMod(&z, coeffs, x)
+		}
+	})
+	b.Run("MulMod+AddMod", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.Clear()
+			for j := len(coeffs) - 1; j >= 0; j-- {
+				z.MulMod(&z, x, m)
+				z.AddMod(&z, &coeffs[j], m)
+			}
+		}
+	})
+}
+
+func TestInterpolate(t *testing.T) {
+	secp256k1P := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	for _, m := range []*Int{NewInt(2), NewInt(97), secp256k1P} {
+		for n := 0; n < 10; n++ {
+			points := make([]Point, n)
+			for i := range points {
+				_, y, err := randNums()
+				if err != nil {
+					t.Fatal(err)
+				}
+				// Distinct x coordinates modulo m (at most m of them).
+				if m.IsUint64() && uint64(i) >= m.Uint64() {
+					points = points[:i]
+					break
+				}
+				points[i].X.SetUint64(uint64(3*i+1)).Mod(&points[i].X, m)
+				if m == secp256k1P {
+					points[i].X.Sub(secp256k1P, NewInt(uint64(i+1)))
+				}
+				points[i].Y = *y
+			}
+			coeffs, err := Interpolate(points, m)
+			if err != nil {
+				t.Fatalf("m=%v n=%d: %v", m, n, err)
+			}
+			if len(coeffs) != len(points) {
+				t.Fatalf("got %d coefficients, want %d", len(coeffs), len(points))
+			}
+			for _, pt := range points {
+				var want Int
+				want.Mod(&pt.Y, m)
+				if got := new(Int).EvalPolyMod(coeffs, &pt.X, m); !got.Eq(&want) {
+					t.Fatalf("m=%v n=%d: p(%v) = %v, want %v", m, n, &pt.X, got, &want)
+				}
+				got, err := new(Int).EvalLagrange(points, &pt.X, m)
+				if err != nil || !got.Eq(&want) {
+					t.Fatalf("m=%v n=%d: EvalLagrange(%v) = %v, %v, want %v", m, n, &pt.X, got, err, &want)
+				}
+			}
+			for i := 0; i < 5; i++ {
+				_, x, _ := randNums()
+				want := new(Int).EvalPolyMod(coeffs, x, m)
+				got, err := new(Int).EvalLagrange(points, x, m)
+				if err != nil || !got.Eq(want) {
+					t.Fatalf("m=%v n=%d: EvalLagrange(%v) = %v, %v, want %v", m, n, x, got, err, want)
+				}
+			}
+		}
+	}
+}
+
+func TestInterpolateErrors(t *testing.T) {
+	m := NewInt(97)
+	dup := []Point{{X: Int{5}, Y: Int{1}}, {X: Int{102}, Y: Int{2}}}
+	if _, err := Interpolate(dup, m); err != ErrDuplicateX {
+		t.Errorf("Interpolate: got %v, want ErrDuplicateX", err)
+	}
+	if _, err := new(Int).EvalLagrange(dup, NewInt(3), m); err != ErrDuplicateX {
+		t.Errorf("EvalLagrange: got %v, want ErrDuplicateX", err)
+	}
+	if _, err := Interpolate(dup[:1], new(Int)); err != ErrDivByZero {
+		t.Errorf("Interpolate: got %v, want ErrDivByZero", err)
+	}
+	if _, err := new(Int).EvalLagrange(dup[:1], NewInt(3), new(Int)); err != ErrDivByZero {
+		t.Errorf("EvalLagrange: got %v, want ErrDivByZero", err)
+	}
+}
@@ -0,0 +1,187 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// ReduceFunc returns x mod m for a 512-bit x, given least significant word
+// first, and some fixed modulus m. The product is passed by value, since a
+// pointer passed through a function value would escape to the heap.
+type ReduceFunc func(x [8]uint64) Int
+
+var (
+	// secp256k1P is the field prime of secp256k1, 2**256 - 2**32 - 977.
+	secp256k1P = Int{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	// p256P is the field prime of NIST P-256,
+	// 2**256 - 2**224 + 2**192 + 2**96 - 1.
+	p256P = Int{0xffffffffffffffff, 0x00000000ffffffff, 0x0000000000000000, 0xffffffff00000001}
+)
+
+// reducers holds the dedicated reductions by modulus.
+var reducers = struct {
+	sync.RWMutex
+	m map[Int]ReduceFunc
+}{m: map[Int]ReduceFunc{
+	secp256k1P: reduceSecp256k1,
+	p256P:      reduceP256,
+}}
+
+// RegisterReducer registers reduce as the reduction to use for products
+// modulo m, in place of the generic Barrett reduction. It takes effect for
+// Moduli created afterwards. A nil reduce removes the registration,
+// including the built-in ones for the secp256k1 and P-256 field primes.
+// ExpModCT is only constant-time if reduce does not branch on its input.
+func RegisterReducer(m *Int, reduce ReduceFunc) {
+	reducers.Lock()
+	defer reducers.Unlock()
+	if reduce == nil {
+		delete(reducers.m, *m)
+		return
+	}
+	reducers.m[*m] = reduce
+}
+
+// specialReducer returns the dedicated reduction for m, or nil if there is
+// none.
+func specialReducer(m *Int) ReduceFunc {
+	reducers.RLock()
+	defer reducers.RUnlock()
+	return reducers.m[*m]
+}
+
+// reduceSecp256k1 returns x mod p for the secp256k1 prime.
+func reduceSecp256k1(x [8]uint64) Int {
+	return reducePseudoMersenne(x, 0x1000003d1)
+}
+
+// NewPseudoMersenne returns a Modulus for the pseudo-Mersenne number
+// m = 2**256 - c, such as the secp256k1 field prime with
+// c = 2**32 + 977. Its products are reduced by folding instead of Barrett
+// reduction. It panics if c == 0.
+func NewPseudoMersenne(c uint64) *Modulus {
+	if c == 0 {
+		panic("uint256: pseudo-Mersenne constant must be non-zero")
+	}
+	var m Int
+	m.SubUint64(&m, c)
+	mod := &Modulus{m: m}
+	mod.special = func(x [8]uint64) Int {
+		return reducePseudoMersenne(x, c)
+	}
+	return mod
+}
+
+// reducePseudoMersenne returns x mod m for m = 2**256 - c, using Crandall
+// reduction: with x = h*2**256 + l and 2**256 = c (mod m), x is congruent
+// to l + h*c, which is folded again until it fits. It does not branch on x.
+func reducePseudoMersenne(x [8]uint64, c uint64) Int {
+	var (
+		r                 Int
+		hi, lo, cc, carry uint64
+	)
+	// r + top*2**256 = l + h*c, with top <= c.
+	for i := 0; i < 4; i++ {
+		hi, lo = bits.Mul64(x[4+i], c)
+		lo, cc = bits.Add64(lo, x[i], 0)
+		hi += cc
+		lo, cc = bits.Add64(lo, carry, 0)
+		hi += cc
+		r[i], carry = lo, hi
+	}
+	// Fold top*c <= c**2, which is below 2**128, into r.
+	hi, lo = bits.Mul64(carry, c)
+	r[0], cc = bits.Add64(r[0], lo, 0)
+	r[1], cc = bits.Add64(r[1], hi, cc)
+	r[2], cc = bits.Add64(r[2], 0, cc)
+	r[3], cc = bits.Add64(r[3], 0, cc)
+	// A carry out is another 2**256 = c; r is then below 2**128, so adding
+	// c cannot carry again.
+	r[0], cc = bits.Add64(r[0], c&-cc, 0)
+	r[1], cc = bits.Add64(r[1], 0, cc)
+	r[2], cc = bits.Add64(r[2], 0, cc)
+	r[3], _ = bits.Add64(r[3], 0, cc)
+	// Subtract m once if r >= m, i.e. if r + c carries.
+	var s Int
+	s[0], cc = bits.Add64(r[0], c, 0)
+	s[1], cc = bits.Add64(r[1], 0, cc)
+	s[2], cc = bits.Add64(r[2], 0, cc)
+	s[3], cc = bits.Add64(r[3], 0, cc)
+	mask := -cc
+	r[0] ^= (r[0] ^ s[0]) & mask
+	r[1] ^= (r[1] ^ s[1]) & mask
+	r[2] ^= (r[2] ^ s[2]) & mask
+	r[3] ^= (r[3] ^ s[3]) & mask
+	return r
+}
+
+// reduceP256 returns x mod p for the NIST P-256 prime, using the Solinas
+// reduction of FIPS 186: the 32-bit words c0..c15 of x are recombined into
+// a handful of 256-bit terms, s1 + 2*s2 + 2*s3 + s4 + s5 - d1 - d2 - d3 - d4,
+// which are summed column by column. It does not branch on x.
+func reduceP256(x [8]uint64) Int {
+	var c [16]int64
+	for i := 0; i < 8; i++ {
+		c[2*i] = int64(uint32(x[i]))
+		c[2*i+1] = int64(x[i] >> 32)
+	}
+	a := [8]int64{
+		c[0] + c[8] + c[9] - c[11] - c[12] - c[13] - c[14],
+		c[1] + c[9] + c[10] - c[12] - c[13] - c[14] - c[15],
+		c[2] + c[10] + c[11] - c[13] - c[14] - c[15],
+		c[3] + 2*c[11] + 2*c[12] + c[13] - c[15] - c[8] - c[9],
+		c[4] + 2*c[12] + 2*c[13] + c[14] - c[9] - c[10],
+		c[5] + 2*c[13] + 2*c[14] + c[15] - c[10] - c[11],
+		c[6] + 3*c[14] + 2*c[15] + c[13] - c[8] - c[9],
+		c[7] + 3*c[15] + c[8] - c[10] - c[11] - c[12] - c[13],
+	}
+	top := p256Carry(&a)
+	// Fold the small signed top back in, using
+	// 2**256 = 2**224 - 2**192 - 2**96 + 1 (mod p); the new top is -1, 0 or 1.
+	a[0] += top
+	a[3] -= top
+	a[6] -= top
+	a[7] += top
+	top = p256Carry(&a)
+
+	r := Int{
+		uint64(a[0]) | uint64(a[1])<<32,
+		uint64(a[2]) | uint64(a[3])<<32,
+		uint64(a[4]) | uint64(a[5])<<32,
+		uint64(a[6]) | uint64(a[7])<<32,
+	}
+	// The value r + top*2**256 is in (-p, 2p): add p if it is negative,
+	// subtract p if it is at least p.
+	var add, sub Int
+	var carry, borrow uint64
+	add[0], carry = bits.Add64(r[0], p256P[0], 0)
+	add[1], carry = bits.Add64(r[1], p256P[1], carry)
+	add[2], carry = bits.Add64(r[2], p256P[2], carry)
+	add[3], _ = bits.Add64(r[3], p256P[3], carry)
+	sub[0], borrow = bits.Sub64(r[0], p256P[0], 0)
+	sub[1], borrow = bits.Sub64(r[1], p256P[1], borrow)
+	sub[2], borrow = bits.Sub64(r[2], p256P[2], borrow)
+	sub[3], borrow = bits.Sub64(r[3], p256P[3], borrow)
+	negMask := uint64(top >> 63)
+	geMask := ^negMask & (-(uint64(top) & 1) | (borrow - 1))
+	for i := range r {
+		r[i] ^= (r[i]^add[i])&negMask | (r[i]^sub[i])&geMask
+	}
+	return r
+}
+
+// p256Carry propagates the carries between the signed 32-bit columns of a,
+// leaving each in [0, 2**32), and returns the signed carry out of the top.
+func p256Carry(a *[8]int64) int64 {
+	var carry int64
+	for i := range a {
+		v := a[i] + carry
+		a[i] = v & 0xffffffff
+		carry = v >> 32
+	}
+	return carry
+}
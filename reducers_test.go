@@ -0,0 +1,102 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestSpecialReducers(t *testing.T) {
+	for _, m := range []*Int{&secp256k1P, &p256P} {
+		mod := NewModulus(m)
+		if mod.special == nil {
+			t.Fatalf("no dedicated reduction for %x", m)
+		}
+		bm := m.ToBig()
+		max := new(Int).SetAllOne()
+		var pm1 Int
+		pm1.SubUint64(m, 1)
+		edge := []*Int{new(Int), NewInt(1), &pm1, m, max}
+		check := func(x, y *Int) {
+			t.Helper()
+			want := new(big.Int).Mul(x.ToBig(), y.ToBig())
+			requireEq(t, want.Mod(want, bm), mod.MulMod(new(Int), x, y), fmt.Sprintf("MulMod(%x, %x)", x, y))
+		}
+		for _, x := range edge {
+			for _, y := range edge {
+				check(x, y)
+			}
+		}
+		for i := 0; i < 2000; i++ {
+			_, x, _ := randHighNums()
+			_, y, _ := randNums()
+			check(x, y)
+			check(x, x)
+		}
+		// Products just above multiples of 2**256 exercise the final folds.
+		for i := uint64(0); i < 64; i++ {
+			x := [8]uint64{i, 0, 0, 0, ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0) - i}
+			want := new(big.Int).SetBits(nil)
+			for j := 7; j >= 0; j-- {
+				want.Lsh(want, 64).Or(want, new(big.Int).SetUint64(x[j]))
+			}
+			got := mod.special(x)
+			requireEq(t, want.Mod(want, bm), &got, fmt.Sprintf("reduce(%x)", x))
+		}
+	}
+}
+
+func TestRegisterReducer(t *testing.T) {
+	m := mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	calls := 0
+	RegisterReducer(m, func(x [8]uint64) Int {
+		calls++
+		return reduce512(&x, m)
+	})
+	mod := NewModulus(m)
+	RegisterReducer(m, nil)
+	x := mustHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	want := new(Int).MulMod(x, x, m)
+	if got := mod.MulMod(new(Int), x, x); !got.Eq(want) || calls != 1 {
+		t.Errorf("registered reducer: got %x after %d calls, want %x after 1", got, calls, want)
+	}
+	if NewModulus(m).special != nil {
+		t.Errorf("reducer still registered after removal")
+	}
+	if NewModulus(&p256P).special == nil {
+		t.Errorf("no built-in reducer for P-256")
+	}
+}
+
+func TestPseudoMersenne(t *testing.T) {
+	for _, c := range []uint64{1, 189, 0x1000003d1, 0x8000000000000001, ^uint64(0)} {
+		mod := NewPseudoMersenne(c)
+		m := mod.Value()
+		if want := new(Int).SubUint64(new(Int), c); !m.Eq(want) {
+			t.Fatalf("NewPseudoMersenne(%d): modulus %x, want %x", c, m, want)
+		}
+		bm := m.ToBig()
+		max := new(Int).SetAllOne()
+		for i := 0; i < 1000; i++ {
+			_, x, _ := randHighNums()
+			_, y, _ := randNums()
+			if i == 0 {
+				x, y = max, max
+			}
+			want := new(big.Int).Mul(x.ToBig(), y.ToBig())
+			requireEq(t, want.Mod(want, bm), mod.MulMod(new(Int), x, y), fmt.Sprintf("MulMod(%x, %x) mod 2**256-%d", x, y, c))
+		}
+		x := mustHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+		requireEq(t, new(big.Int).Exp(x.ToBig(), x.ToBig(), bm), mod.ExpMod(new(Int), x, x), "ExpMod")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewPseudoMersenne(0) did not panic")
+		}
+	}()
+	NewPseudoMersenne(0)
+}
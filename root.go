@@ -0,0 +1,60 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// Root sets z to the n-th root of x, rounded down, and returns z.
+// If n == 0, z is set to 0.
+func (z *Int) Root(x *Int, n uint) *Int {
+	if n == 0 {
+		return z.Clear()
+	}
+	bitLen := uint(x.BitLen())
+	switch {
+	case n == 1:
+		return z.Set(x)
+	case bitLen <= n:
+		// x < 2**n, so the root is 1, or 0 for x == 0.
+		if x.IsZero() {
+			return z.Clear()
+		}
+		return z.SetOne()
+	}
+	var (
+		nm1 = NewInt(uint64(n - 1))
+		nn  = NewInt(uint64(n))
+		xx  = *x
+		y   Int
+		t   Int
+		p   Int
+	)
+	// Newton's iteration y' = ((n-1)*y + x/y**(n-1)) / n decreases
+	// monotonically to the floor of the root from any start above it, here
+	// 2**ceil(bitLen/n). (n-1)*y cannot overflow, as y <= 2**128.
+	y.Lsh(NewInt(1), (bitLen+n-1)/n)
+	for {
+		if _, overflow := p.PowOverflow(&y, nm1); overflow {
+			p.Clear()
+		} else {
+			p.Div(&xx, &p)
+		}
+		t.Mul(&y, nm1)
+		t.Add(&t, &p)
+		t.Div(&t, nn)
+		if !t.Lt(&y) {
+			return z.Set(&y)
+		}
+		y = t
+	}
+}
+
+// Sqrt sets z to the square root of x, rounded down, and returns z.
+func (z *Int) Sqrt(x *Int) *Int {
+	return z.Root(x, 2)
+}
+
+// Cbrt sets z to the cube root of x, rounded down, and returns z.
+func (z *Int) Cbrt(x *Int) *Int {
+	return z.Root(x, 3)
+}
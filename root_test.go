@@ -0,0 +1,59 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// checkRoot fails unless r**n <= x < (r+1)**n.
+func checkRoot(t *testing.T, r, x *Int, n uint) {
+	t.Helper()
+	bn := big.NewInt(int64(n))
+	lo := new(big.Int).Exp(r.ToBig(), bn, nil)
+	hi := new(big.Int).Exp(new(big.Int).Add(r.ToBig(), big.NewInt(1)), bn, nil)
+	if lo.Cmp(x.ToBig()) > 0 || hi.Cmp(x.ToBig()) <= 0 {
+		t.Fatalf("Root(%x, %d): got %x", x, n, r)
+	}
+}
+
+func TestRoot(t *testing.T) {
+	max := new(Int).SetAllOne()
+	var xs []*Int
+	for _, x := range []uint64{0, 1, 2, 3, 4, 7, 8, 9, 26, 27, 28, 1 << 63, ^uint64(0)} {
+		xs = append(xs, NewInt(x))
+	}
+	xs = append(xs, max, new(Int).Lsh(NewInt(1), 255), new(Int).Lsh(NewInt(1), 128),
+		new(Int).Sub(new(Int).Lsh(NewInt(1), 128), NewInt(1)))
+	for i := 0; i < 200; i++ {
+		_, x, _ := randNums()
+		xs = append(xs, x.Rsh(x, uint(i%256)))
+	}
+	for _, x := range xs {
+		for _, n := range []uint{1, 2, 3, 4, 5, 7, 16, 63, 64, 128, 255, 256, 257, 1 << 20} {
+			checkRoot(t, new(Int).Root(x, n), x, n)
+		}
+		want := new(big.Int).Sqrt(x.ToBig())
+		requireEq(t, want, new(Int).Sqrt(x), fmt.Sprintf("Sqrt(%x)", x))
+		checkRoot(t, new(Int).Cbrt(x), x, 3)
+	}
+	if z := new(Int).Root(max, 0); !z.IsZero() {
+		t.Errorf("Root(max, 0): got %x, want 0", z)
+	}
+}
+
+func BenchmarkRoot(b *testing.B) {
+	x := new(Int).SetAllOne()
+	for _, n := range []uint{2, 3, 7} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			var z Int
+			for i := 0; i < b.N; i++ {
+				z.Root(x, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// RoundingMode selects how a division with a remainder is rounded.
+type RoundingMode uint8
+
+const (
+	RoundDown     RoundingMode = iota // toward zero, the floor
+	RoundUp                           // away from zero, the ceiling
+	RoundHalfEven                     // to nearest, ties to the even quotient
+	RoundHalfUp                       // to nearest, ties away from zero
+)
+
+// String returns the name of the rounding mode.
+func (r RoundingMode) String() string {
+	switch r {
+	case RoundDown:
+		return "RoundDown"
+	case RoundUp:
+		return "RoundUp"
+	case RoundHalfEven:
+		return "RoundHalfEven"
+	case RoundHalfUp:
+		return "RoundHalfUp"
+	}
+	return "RoundingMode(?)"
+}
+
+// roundUp reports whether the quotient q of a division by d with remainder
+// r < d should be incremented under mode.
+func (mode RoundingMode) roundUp(qOdd bool, r, d *Int) bool {
+	if r.IsZero() {
+		return false
+	}
+	switch mode {
+	case RoundUp:
+		return true
+	case RoundHalfEven, RoundHalfUp:
+		// Compare 2r with d as r with d-r, which cannot overflow.
+		var half Int
+		half.Sub(d, r)
+		if c := r.Cmp(&half); c != 0 {
+			return c > 0
+		}
+		return mode == RoundHalfUp || qOdd
+	}
+	return false
+}
+
+// MulDivRounding sets z to x*y / d, rounded according to mode, and returns
+// z, and whether the rounded quotient overflowed 256 bits, in which case z
+// holds its low 256 bits. The product is computed in full 512 bits.
+// If d == 0, z is set to 0 and overflow is false (OBS: differs from the big.Int)
+func (z *Int) MulDivRounding(x, y, d *Int, mode RoundingMode) (*Int, bool) {
+	if x.IsZero() || y.IsZero() || d.IsZero() {
+		return z.Clear(), false
+	}
+	p := umul(x, y)
+	var quot [8]uint64
+	var rem Int
+	if p[4]|p[5]|p[6]|p[7] == 0 && (&Int{p[0], p[1], p[2], p[3]}).Lt(d) {
+		rem = Int{p[0], p[1], p[2], p[3]}
+	} else {
+		rem = udivrem(quot[:], p[:], d)
+	}
+	overflow := (quot[4] | quot[5] | quot[6] | quot[7]) != 0
+	copy(z[:], quot[:4])
+	if mode.roundUp(quot[0]&1 == 1, &rem, d) {
+		if z.AddUint64(z, 1).IsZero() {
+			overflow = true
+		}
+	}
+	return z, overflow
+}
+
+// MulDivRoundingUp sets z to the ceiling of x*y / d and returns z, and
+// whether the quotient overflowed 256 bits. It is MulDivRounding with
+// RoundUp.
+// If d == 0, z is set to 0 and overflow is false (OBS: differs from the big.Int)
+func (z *Int) MulDivRoundingUp(x, y, d *Int) (*Int, bool) {
+	return z.MulDivRounding(x, y, d, RoundUp)
+}
+
+// DivRound sets z to x / y, rounded according to mode, and returns z. The
+// rounded quotient cannot overflow.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) DivRound(x, y *Int, mode RoundingMode) *Int {
+	if y.IsZero() {
+		return z.Clear()
+	}
+	yy := *y
+	q, r := DivMod(x, &yy)
+	z.Set(&q)
+	if mode.roundUp(q[0]&1 == 1, &r, &yy) {
+		z.AddUint64(z, 1)
+	}
+	return z
+}
@@ -0,0 +1,122 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// bigDivRound returns n / d rounded according to mode.
+func bigDivRound(n, d *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	switch mode {
+	case RoundUp:
+		q.Add(q, big.NewInt(1))
+	case RoundHalfEven:
+		if c := new(big.Int).Lsh(r, 1).Cmp(d); c > 0 || (c == 0 && q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(1))
+		}
+	case RoundHalfUp:
+		if new(big.Int).Lsh(r, 1).Cmp(d) >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+var allRoundingModes = []RoundingMode{RoundDown, RoundUp, RoundHalfEven, RoundHalfUp}
+
+func TestMulDivRounding(t *testing.T) {
+	check := func(x, y, d *Int, mode RoundingMode) {
+		t.Helper()
+		want := new(big.Int)
+		if !d.IsZero() {
+			want = bigDivRound(new(big.Int).Mul(x.ToBig(), y.ToBig()), d.ToBig(), mode)
+		}
+		wantOverflow := want.Cmp(bigtt256) >= 0
+		want.Mod(want, bigtt256)
+		got, overflow := new(Int).MulDivRounding(x, y, d, mode)
+		msg := fmt.Sprintf("MulDivRounding(%x, %x, %x, %v)", x, y, d, mode)
+		if overflow != wantOverflow {
+			t.Fatalf("%s: got overflow %v, want %v", msg, overflow, wantOverflow)
+		}
+		requireEq(t, want, got, msg)
+		if mode == RoundUp {
+			got, overflow = new(Int).MulDivRoundingUp(x, y, d)
+			if overflow != wantOverflow {
+				t.Fatalf("MulDivRoundingUp: got overflow %v, want %v", overflow, wantOverflow)
+			}
+			requireEq(t, want, got, "MulDivRoundingUp")
+		}
+	}
+	max := new(Int).SetAllOne()
+	for _, mode := range allRoundingModes {
+		// Exact halves round to the even quotient.
+		for x := uint64(0); x < 12; x++ {
+			check(NewInt(x), NewInt(1), NewInt(2), mode)
+			check(NewInt(x), NewInt(3), NewInt(4), mode)
+		}
+		check(max, max, max, mode)
+		check(max, max, new(Int).Sub(max, NewInt(1)), mode)
+		check(max, NewInt(2), NewInt(2), mode)
+		check(max, NewInt(3), NewInt(2), mode)
+		check(max, NewInt(1), NewInt(0), mode)
+		check(new(Int).Lsh(NewInt(1), 255), NewInt(3), NewInt(2), mode)
+		// x*7 = 5*max + 2, so x*7/5 is max with remainder 2: rounding up
+		// overflows while the floor does not.
+		n := new(big.Int).Mul(max.ToBig(), big.NewInt(5))
+		n.Add(n, big.NewInt(2))
+		x, _ := FromBig(n.Quo(n, big.NewInt(7)))
+		check(x, NewInt(7), NewInt(5), mode)
+		for i := 0; i < 500; i++ {
+			_, x, _ := randNums()
+			_, y, _ := randHighNums()
+			_, d, _ := randNums()
+			check(x, y, d, mode)
+		}
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	check := func(x, y *Int, mode RoundingMode) {
+		t.Helper()
+		want := new(big.Int)
+		if !y.IsZero() {
+			want = bigDivRound(x.ToBig(), y.ToBig(), mode)
+		}
+		requireEq(t, want, new(Int).DivRound(x, y, mode), fmt.Sprintf("DivRound(%x, %x, %v)", x, y, mode))
+	}
+	max := new(Int).SetAllOne()
+	for _, mode := range allRoundingModes {
+		for x := uint64(0); x < 12; x++ {
+			for _, y := range []uint64{0, 1, 2, 3, 4} {
+				check(NewInt(x), NewInt(y), mode)
+			}
+		}
+		check(max, NewInt(2), mode)
+		check(max, max, mode)
+		check(max, new(Int).Rsh(max, 1), mode)
+		check(new(Int).Rsh(max, 1), max, mode)
+		check(new(Int).Rsh(max, 2), new(Int).Rsh(max, 1), mode)
+		for i := 0; i < 500; i++ {
+			_, x, _ := randNums()
+			_, y, _ := randNums()
+			check(x, y.Rsh(y, uint(i%256)), mode)
+		}
+	}
+}
+
+func TestRoundingModeString(t *testing.T) {
+	for mode, want := range map[RoundingMode]string{RoundDown: "RoundDown", RoundUp: "RoundUp", RoundHalfEven: "RoundHalfEven", RoundHalfUp: "RoundHalfUp", 7: "RoundingMode(?)"} {
+		if got := mode.String(); got != want {
+			t.Errorf("RoundingMode(%d).String(): got %q, want %q", uint8(mode), got, want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "math/bits"
+
+// This file implements constant-time modular inversion with the safegcd
+// algorithm of Bernstein and Yang ("Fast constant-time gcd computation and
+// modular inversion", 2019), following the variant used by libsecp256k1:
+// divsteps are done in batches of 59 on the low bits of f and g, and the
+// resulting transition matrix is then applied to the full values, which are
+// kept as five signed 62-bit limbs.
+
+const m62 = ^uint64(0) >> 2
+
+// signed62 is a signed number v[0] + v[1]*2**62 + ... + v[4]*2**248, with
+// the limbs normally in [0, 2**62), except the last, which carries the sign.
+type signed62 [5]int64
+
+func (s *signed62) setInt(x *Int) {
+	s[0] = int64(x[0] & m62)
+	s[1] = int64((x[0]>>62 | x[1]<<2) & m62)
+	s[2] = int64((x[1]>>60 | x[2]<<4) & m62)
+	s[3] = int64((x[2]>>58 | x[3]<<6) & m62)
+	s[4] = int64(x[3] >> 56)
+}
+
+// int sets z to s, which must be in [0, 2**256), and returns z.
+func (s *signed62) int(z *Int) *Int {
+	z[0] = uint64(s[0]) | uint64(s[1])<<62
+	z[1] = uint64(s[1])>>2 | uint64(s[2])<<60
+	z[2] = uint64(s[2])>>4 | uint64(s[3])<<58
+	z[3] = uint64(s[3])>>6 | uint64(s[4])<<56
+	return z
+}
+
+// int128 is a signed 128-bit number in two's complement.
+type int128 struct{ hi, lo uint64 }
+
+// mul128 returns the 128-bit product of a and b.
+func mul128(a, b int64) int128 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	hi -= uint64(a>>63)&uint64(b) + uint64(b>>63)&uint64(a)
+	return int128{hi, lo}
+}
+
+func (x int128) add(y int128) int128 {
+	lo, c := bits.Add64(x.lo, y.lo, 0)
+	return int128{x.hi + y.hi + c, lo}
+}
+
+// rsh62 returns x >> 62, sign-extended.
+func (x int128) rsh62() int128 {
+	return int128{uint64(int64(x.hi) >> 62), x.lo>>62 | x.hi<<2}
+}
+
+// trans2x2 is the transition matrix of a batch of divsteps, scaled by
+// 2**62.
+type trans2x2 struct{ u, v, q, r int64 }
+
+// divsteps59 performs 59 divsteps on the low 64 bits f0, g0 of f and g,
+// starting from zeta = -(delta+1/2), and returns the new zeta and the
+// transition matrix. Every step does the same work regardless of the data.
+func divsteps59(zeta int64, f0, g0 uint64, t *trans2x2) int64 {
+	// The matrix starts as the identity times 8, so that after 59 steps it
+	// is scaled by 2**62.
+	u, v, q, r := uint64(8), uint64(0), uint64(0), uint64(8)
+	f, g := f0, g0
+	for i := 3; i < 62; i++ {
+		// mask1 is set if zeta < 0, mask2 if g is odd.
+		mask1 := uint64(zeta >> 63)
+		mask2 := -(g & 1)
+		// Conditionally negate f, u, v, and add them to g, q, r.
+		x := (f ^ mask1) - mask1
+		y := (u ^ mask1) - mask1
+		z := (v ^ mask1) - mask1
+		g += x & mask2
+		q += y & mask2
+		r += z & mask2
+		// If zeta < 0 and g was odd, swap roles: zeta becomes -zeta-2 and
+		// the new g, q, r are added to f, u, v. Otherwise zeta decrements.
+		mask1 &= mask2
+		zeta = (zeta ^ int64(mask1)) - 1
+		f += g & mask1
+		u += q & mask1
+		v += r & mask1
+		g >>= 1
+		u <<= 1
+		v <<= 1
+	}
+	*t = trans2x2{int64(u), int64(v), int64(q), int64(r)}
+	return zeta
+}
+
+// safegcd holds a modulus in signed62 form with its inverse mod 2**62.
+type safegcd struct {
+	m    signed62
+	mInv uint64
+}
+
+// updateDE sets d, e to t*[d, e] / 2**62 modulo m. The inputs must be in
+// (-2m, m), and so are the outputs.
+func (s *safegcd) updateDE(d, e *signed62, t *trans2x2) {
+	// md, me start as zero; plus [u, q] if d is negative; plus [v, r] if e
+	// is negative.
+	sd, se := d[4]>>63, e[4]>>63
+	md := (t.u & sd) + (t.v & se)
+	me := (t.q & sd) + (t.r & se)
+	cd := mul128(t.u, d[0]).add(mul128(t.v, e[0]))
+	ce := mul128(t.q, d[0]).add(mul128(t.r, e[0]))
+	// Correct md, me so that t*[d, e] + m*[md, me] has 62 zero low bits.
+	md -= int64((s.mInv*cd.lo + uint64(md)) & m62)
+	me -= int64((s.mInv*ce.lo + uint64(me)) & m62)
+	cd = cd.add(mul128(s.m[0], md)).rsh62()
+	ce = ce.add(mul128(s.m[0], me)).rsh62()
+	for i := 1; i < 5; i++ {
+		cd = cd.add(mul128(t.u, d[i])).add(mul128(t.v, e[i])).add(mul128(s.m[i], md))
+		ce = ce.add(mul128(t.q, d[i])).add(mul128(t.r, e[i])).add(mul128(s.m[i], me))
+		d[i-1] = int64(cd.lo & m62)
+		e[i-1] = int64(ce.lo & m62)
+		cd, ce = cd.rsh62(), ce.rsh62()
+	}
+	d[4], e[4] = int64(cd.lo), int64(ce.lo)
+}
+
+// updateFG sets f, g to t*[f, g] / 2**62, which is exact.
+func updateFG(f, g *signed62, t *trans2x2) {
+	cf := mul128(t.u, f[0]).add(mul128(t.v, g[0])).rsh62()
+	cg := mul128(t.q, f[0]).add(mul128(t.r, g[0])).rsh62()
+	for i := 1; i < 5; i++ {
+		cf = cf.add(mul128(t.u, f[i])).add(mul128(t.v, g[i]))
+		cg = cg.add(mul128(t.q, f[i])).add(mul128(t.r, g[i]))
+		f[i-1] = int64(cf.lo & m62)
+		g[i-1] = int64(cg.lo & m62)
+		cf, cg = cf.rsh62(), cg.rsh62()
+	}
+	f[4], g[4] = int64(cf.lo), int64(cg.lo)
+}
+
+// normalize brings r from (-2m, m) to [0, m), negating it first if sign is
+// negative.
+func (s *safegcd) normalize(r *signed62, sign int64) {
+	// Add m if r is negative, then negate if requested, which brings r to
+	// (-m, m).
+	add := r[4] >> 63
+	for i := range r {
+		r[i] += s.m[i] & add
+	}
+	neg := sign >> 63
+	for i := range r {
+		r[i] = (r[i] ^ neg) - neg
+	}
+	r.carry()
+	// Add m again if r is still negative.
+	add = r[4] >> 63
+	for i := range r {
+		r[i] += s.m[i] & add
+	}
+	r.carry()
+}
+
+// carry propagates the top bits of the limbs, bringing the lower ones to
+// [0, 2**62).
+func (r *signed62) carry() {
+	for i := 0; i < 4; i++ {
+		r[i+1] += r[i] >> 62
+		r[i] &= int64(m62)
+	}
+}
+
+// ModInverseCT sets z to the multiplicative inverse of x modulo the odd
+// prime m, and returns z. It runs in time independent of the value of x, so
+// it is suitable for secret data. x should already be reduced modulo m; if
+// it is not, it is first reduced in variable time. If x == 0 mod m, z is
+// set to 0. For odd composite m the result is the inverse whenever x is
+// coprime to m, and meaningless otherwise; use ModInverse to detect that.
+// If m is even, including m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) ModInverseCT(x, m *Int) *Int {
+	if m[0]&1 == 0 {
+		return z.Clear()
+	}
+	var s safegcd
+	s.m.setInt(m)
+	// Newton iteration for m**-1 mod 2**64, see MontContext.
+	inv := m[0]
+	for i := 0; i < 6; i++ {
+		inv *= 2 - m[0]*inv
+	}
+	s.mInv = inv & m62
+
+	var xr Int
+	xr.Mod(x, m)
+	// Start with d = 0, e = 1, f = m, g = x and zeta = -1 (delta = 1/2).
+	var d, e, g signed62
+	e[0] = 1
+	f := s.m
+	g.setInt(&xr)
+	zeta := int64(-1)
+	// 10 batches of 59 divsteps suffice for 256-bit inputs, after which
+	// g = 0, f = +-gcd(m, x) and d = +-x**-1 mod m.
+	var t trans2x2
+	for i := 0; i < 10; i++ {
+		zeta = divsteps59(zeta, uint64(f[0]), uint64(g[0]), &t)
+		s.updateDE(&d, &e, &t)
+		updateFG(&f, &g, &t)
+	}
+	s.normalize(&d, f[4])
+	d.int(z)
+	return z
+}
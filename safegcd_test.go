@@ -0,0 +1,95 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestModInverseCT(t *testing.T) {
+	moduli := []*Int{
+		mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		mustHex("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+		mustHex("0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47"),
+		mustHex("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff43"),
+		NewInt(3),
+		NewInt(65537),
+		NewInt(0xffffffffffffffc5),
+	}
+	for _, m := range moduli {
+		bm := m.ToBig()
+		check := func(x *Int) {
+			t.Helper()
+			got := new(Int).ModInverseCT(x, m)
+			want := new(big.Int).ModInverse(x.ToBig(), bm)
+			if want == nil {
+				want = new(big.Int)
+			}
+			requireEq(t, want, got, fmt.Sprintf("ModInverseCT(%x, %x)", x, m))
+		}
+		var pm1 Int
+		pm1.SubUint64(m, 1)
+		for _, x := range []*Int{new(Int), NewInt(1), NewInt(2), &pm1, m, new(Int).SetAllOne()} {
+			check(x)
+		}
+		for i := 0; i < 200; i++ {
+			_, x, err := randHighNums()
+			if err != nil {
+				t.Fatal(err)
+			}
+			check(x.Mod(x, m))
+		}
+	}
+	// Odd composite moduli, with x coprime to them.
+	for i := 0; i < 500; i++ {
+		_, m, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, x, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m[0] |= 1
+		if !new(Int).GCD(x, m).Eq(NewInt(1)) {
+			continue
+		}
+		want, _ := new(Int).ModInverse(x, m)
+		if got := new(Int).ModInverseCT(x, m); !got.Eq(want) {
+			t.Fatalf("ModInverseCT(%x, %x): got %x, want %x", x, m, got, want)
+		}
+	}
+	for _, m := range []*Int{new(Int), NewInt(4)} {
+		if got := new(Int).ModInverseCT(NewInt(3), m); !got.IsZero() {
+			t.Errorf("ModInverseCT(3, %v): got %v, want 0", m, got)
+		}
+	}
+}
+
+func BenchmarkModInverse(b *testing.B) {
+	p := mustHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	x := mustHex("0x12cbafcee8f60f9f3fa308c90fde8d298772ffea667aa6bc109d5c661e7929a5")
+	b.Run("Euclid", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ModInverse(x, p)
+		}
+	})
+	b.Run("CT", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.ModInverseCT(x, p)
+		}
+	})
+	b.Run("big", func(b *testing.B) {
+		bx, bp := x.ToBig(), p.ToBig()
+		z := new(big.Int)
+		for i := 0; i < b.N; i++ {
+			z.ModInverse(bx, bp)
+		}
+	})
+}
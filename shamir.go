@@ -0,0 +1,54 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrShamirParams is returned by Split unless 0 < k <= n < p.
+	ErrShamirParams = errors.New("secret sharing needs 0 < k <= n < p")
+	// ErrShamirSecret is returned by Split if the secret is not below p.
+	ErrShamirSecret = errors.New("secret not less than p")
+)
+
+// Split splits secret into n shares over the prime field of order p, any k
+// of which are enough to recover it with Combine, while fewer reveal
+// nothing about it. The random polynomial coefficients are read from r
+// (typically crypto/rand.Reader). The shares are the points (i, f(i)) for
+// i = 1..n, where f is the polynomial of degree k-1 with f(0) = secret.
+func Split(secret *Int, n, k int, p *Int, r io.Reader) ([]Point, error) {
+	if k <= 0 || n < k || !p.GtUint64(uint64(n)) {
+		return nil, ErrShamirParams
+	}
+	if !secret.Lt(p) {
+		return nil, ErrShamirSecret
+	}
+	coeffs := make([]Int, k)
+	coeffs[0] = *secret
+	for i := 1; i < k; i++ {
+		c, err := RandMod(r, p)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = *c
+	}
+	shares := make([]Point, n)
+	for i := range shares {
+		shares[i].X.SetUint64(uint64(i + 1))
+		shares[i].Y.EvalPolyMod(coeffs, &shares[i].X, p)
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from shares produced by Split with the same
+// prime p. It needs at least k distinct shares; with fewer, it silently
+// returns an unrelated value. It returns ErrDuplicateX if two shares have
+// the same x coordinate.
+func Combine(shares []Point, p *Int) (*Int, error) {
+	return new(Int).EvalLagrange(shares, new(Int), p)
+}
@@ -0,0 +1,66 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestShamir(t *testing.T) {
+	p := &Int{0xfffffffefffffc2f, ^uint64(0), ^uint64(0), ^uint64(0)}
+	for _, tc := range []struct{ n, k int }{{1, 1}, {3, 2}, {5, 3}, {10, 10}} {
+		secret, err := RandMod(rand.Reader, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shares, err := Split(secret, tc.n, tc.k, p, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shares) != tc.n {
+			t.Fatalf("got %d shares, want %d", len(shares), tc.n)
+		}
+		// Any k shares, taken from the end, recover the secret.
+		got, err := Combine(shares[tc.n-tc.k:], p)
+		if err != nil || !got.Eq(secret) {
+			t.Errorf("n=%d k=%d: Combine = %v, %v, want %v", tc.n, tc.k, got, err, secret)
+		}
+		if got, err := Combine(shares, p); err != nil || !got.Eq(secret) {
+			t.Errorf("n=%d k=%d: Combine(all) = %v, %v, want %v", tc.n, tc.k, got, err, secret)
+		}
+		if tc.k > 1 {
+			if got, _ := Combine(shares[:tc.k-1], p); got.Eq(secret) {
+				t.Errorf("n=%d k=%d: recovered secret from k-1 shares", tc.n, tc.k)
+			}
+		}
+	}
+}
+
+func TestShamirErrors(t *testing.T) {
+	p := NewInt(11)
+	for _, tc := range []struct {
+		secret *Int
+		n, k   int
+		want   error
+	}{
+		{NewInt(1), 3, 0, ErrShamirParams},
+		{NewInt(1), 2, 3, ErrShamirParams},
+		{NewInt(1), 11, 2, ErrShamirParams},
+		{NewInt(11), 3, 2, ErrShamirSecret},
+	} {
+		if _, err := Split(tc.secret, tc.n, tc.k, p, rand.Reader); err != tc.want {
+			t.Errorf("Split(%v, %d, %d): got %v, want %v", tc.secret, tc.n, tc.k, err, tc.want)
+		}
+	}
+	if _, err := Split(NewInt(1), 3, 2, p, bytes.NewReader(nil)); err == nil {
+		t.Errorf("expected error from empty reader")
+	}
+	shares, _ := Split(NewInt(5), 3, 2, p, rand.Reader)
+	if _, err := Combine([]Point{shares[0], shares[0]}, p); err != ErrDuplicateX {
+		t.Errorf("Combine: got %v, want ErrDuplicateX", err)
+	}
+}
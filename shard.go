@@ -0,0 +1,48 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+// mix64 is the 64-bit finalizer of MurmurHash3.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// JumpHash maps key to a bucket in [0, buckets) using the jump consistent
+// hash of Lamping and Veach. Growing the number of buckets from n to n+1
+// moves only the keys that land in the new bucket.
+// All 256 bits of the key are mixed into the 64-bit seed of the algorithm,
+// so keys which differ only outside some 64-bit window still spread
+// evenly, unlike with a truncated key.
+// It returns -1 if buckets <= 0.
+func JumpHash(key *Int, buckets int) int {
+	if buckets <= 0 {
+		return -1
+	}
+	seed := mix64(key[0])
+	seed = mix64(seed ^ key[1])
+	seed = mix64(seed ^ key[2])
+	seed = mix64(seed ^ key[3])
+
+	b, j := int64(-1), int64(0)
+	for j < int64(buckets) {
+		b = j
+		seed = seed*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((seed>>33)+1)))
+	}
+	return int(b)
+}
+
+// RingPosition scales key from [0, 2^256) onto [0, n), returning
+// floor(key * n / 2^256). The mapping is monotone, so it places keys on a
+// hash ring of n slots without the bias of key mod n.
+func RingPosition(key *Int, n uint64) uint64 {
+	p := umul(key, &Int{n})
+	return p[4]
+}
@@ -0,0 +1,69 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestJumpHash(t *testing.T) {
+	const keys = 10000
+	const buckets = 10
+	var counts [buckets + 1]int
+	for i := 0; i < keys; i++ {
+		_, key, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := JumpHash(key, buckets)
+		if b < 0 || b >= buckets {
+			t.Fatalf("bucket out of range: %d", b)
+		}
+		counts[b]++
+		// Consistency: one more bucket either keeps the key or moves it to
+		// the new bucket.
+		if b2 := JumpHash(key, buckets+1); b2 != b && b2 != buckets {
+			t.Fatalf("key %x moved from %d to %d", key, b, b2)
+		}
+	}
+	for b := 0; b < buckets; b++ {
+		if counts[b] < keys/buckets*8/10 || counts[b] > keys/buckets*12/10 {
+			t.Errorf("bucket %d unbalanced: %d", b, counts[b])
+		}
+	}
+	// Keys differing only above the low 64 bits should spread too.
+	seen := make(map[int]bool)
+	for i := uint64(0); i < 100; i++ {
+		seen[JumpHash(&Int{7, i, 0, 0}, 1000)] = true
+	}
+	if len(seen) < 80 {
+		t.Errorf("only %d distinct buckets for keys sharing the low word", len(seen))
+	}
+	if JumpHash(new(Int), 0) != -1 {
+		t.Error("expected -1 for no buckets")
+	}
+	if JumpHash(new(Int).SetAllOne(), 1) != 0 {
+		t.Error("expected 0 for a single bucket")
+	}
+}
+
+func TestRingPosition(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		b, key, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := uint64(i*7919 + 1)
+		want := new(big.Int).Mul(b, new(big.Int).SetUint64(n))
+		want.Rsh(want, 256)
+		if got := RingPosition(key, n); got != want.Uint64() {
+			t.Fatalf("RingPosition(%x, %d): got %d, want %d", key, n, got, want)
+		}
+	}
+	if got := RingPosition(new(Int).SetAllOne(), 1<<63); got != 1<<63-1 {
+		t.Errorf("got %d", got)
+	}
+}
@@ -0,0 +1,117 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"errors"
+	"sync"
+)
+
+// StackLimit is the capacity of a Stack, as in the EVM.
+const StackLimit = 1024
+
+var (
+	ErrStackOverflow  = errors.New("stack overflow")
+	ErrStackUnderflow = errors.New("stack underflow")
+)
+
+var stackPool = sync.Pool{
+	New: func() interface{} {
+		return &Stack{data: make([]Int, 0, StackLimit)}
+	},
+}
+
+// Stack is a fixed-capacity operand stack of Ints for interpreter loops.
+// Its storage is allocated once with capacity StackLimit and recycled
+// through a pool, so pushing and popping never allocate. Operations
+// report ErrStackOverflow or ErrStackUnderflow instead of panicking.
+//
+// The element pointers returned by Peek and Back stay valid until the
+// element is popped; arithmetic may be performed on them in place, e.g.
+//
+//	x, _ := s.Pop()
+//	y, _ := s.Peek()
+//	y.Add(&x, y)
+type Stack struct {
+	data []Int
+}
+
+// NewStack returns an empty stack from the pool.
+func NewStack() *Stack {
+	return stackPool.Get().(*Stack)
+}
+
+// Release returns s to the pool. s must not be used afterwards.
+func (s *Stack) Release() {
+	s.data = s.data[:0]
+	stackPool.Put(s)
+}
+
+// Len returns the number of elements on the stack.
+func (s *Stack) Len() int {
+	return len(s.data)
+}
+
+// Reset removes all elements.
+func (s *Stack) Reset() {
+	s.data = s.data[:0]
+}
+
+// Push pushes a copy of x.
+func (s *Stack) Push(x *Int) error {
+	if len(s.data) == StackLimit {
+		return ErrStackOverflow
+	}
+	s.data = append(s.data, *x)
+	return nil
+}
+
+// Pop removes and returns the top element.
+func (s *Stack) Pop() (Int, error) {
+	if len(s.data) == 0 {
+		return Int{}, ErrStackUnderflow
+	}
+	x := s.data[len(s.data)-1]
+	s.data = s.data[:len(s.data)-1]
+	return x, nil
+}
+
+// Peek returns a pointer to the top element.
+func (s *Stack) Peek() (*Int, error) {
+	return s.Back(0)
+}
+
+// Back returns a pointer to the n-th element from the top; Back(0) is the
+// top element.
+func (s *Stack) Back(n int) (*Int, error) {
+	if n < 0 || n >= len(s.data) {
+		return nil, ErrStackUnderflow
+	}
+	return &s.data[len(s.data)-1-n], nil
+}
+
+// Dup pushes a copy of the n-th element, counting the top as 1, as the EVM
+// DUPn instructions.
+func (s *Stack) Dup(n int) error {
+	if n < 1 || n > len(s.data) {
+		return ErrStackUnderflow
+	}
+	if len(s.data) == StackLimit {
+		return ErrStackOverflow
+	}
+	s.data = append(s.data, s.data[len(s.data)-n])
+	return nil
+}
+
+// Swap exchanges the top element with the (n+1)-th, as the EVM SWAPn
+// instructions.
+func (s *Stack) Swap(n int) error {
+	if n < 1 || n >= len(s.data) {
+		return ErrStackUnderflow
+	}
+	top := len(s.data) - 1
+	s.data[top], s.data[top-n] = s.data[top-n], s.data[top]
+	return nil
+}
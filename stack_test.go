@@ -0,0 +1,91 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import "testing"
+
+func TestStack(t *testing.T) {
+	s := NewStack()
+	defer s.Release()
+
+	if _, err := s.Pop(); err != ErrStackUnderflow {
+		t.Errorf("Pop on empty stack: got %v", err)
+	}
+	if _, err := s.Peek(); err != ErrStackUnderflow {
+		t.Errorf("Peek on empty stack: got %v", err)
+	}
+	for i := uint64(1); i <= 3; i++ {
+		if err := s.Push(NewInt(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Stack (top first): 3 2 1
+	if err := s.Dup(3); err != nil {
+		t.Fatal(err)
+	}
+	// 1 3 2 1
+	if err := s.Swap(2); err != nil {
+		t.Fatal(err)
+	}
+	// 2 3 1 1
+	x, _ := s.Pop()
+	y, _ := s.Peek()
+	y.Mul(&x, y)
+	// 6 1 1
+	for _, want := range []uint64{6, 1, 1} {
+		if got, err := s.Pop(); err != nil || !got.Eq(NewInt(want)) {
+			t.Errorf("Pop: got %v, %v, want %d", &got, err, want)
+		}
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected empty stack, got %d", s.Len())
+	}
+
+	if err := s.Push(NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range []error{s.Dup(0), s.Dup(2), s.Swap(0), s.Swap(1)} {
+		if err != ErrStackUnderflow {
+			t.Errorf("expected ErrStackUnderflow, got %v", err)
+		}
+	}
+	if _, err := s.Back(1); err != ErrStackUnderflow {
+		t.Errorf("Back: got %v", err)
+	}
+	for s.Len() < StackLimit {
+		if err := s.Dup(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Push(NewInt(1)); err != ErrStackOverflow {
+		t.Errorf("Push on full stack: got %v", err)
+	}
+	if err := s.Dup(1); err != ErrStackOverflow {
+		t.Errorf("Dup on full stack: got %v", err)
+	}
+	s.Reset()
+	if s.Len() != 0 {
+		t.Errorf("expected empty stack after Reset")
+	}
+}
+
+func TestStackNoAlloc(t *testing.T) {
+	s := NewStack()
+	defer s.Release()
+	x := NewInt(42)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 100; i++ {
+			_ = s.Push(x)
+		}
+		_ = s.Dup(16)
+		_ = s.Swap(16)
+		for s.Len() > 0 {
+			_, _ = s.Pop()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations, want 0", allocs)
+	}
+}
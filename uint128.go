@@ -0,0 +1,191 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Uint128 is a 128-bit unsigned integer, in the little-endian limb order of
+// Int. It is meant for storing and computing on 128-bit quantities without
+// the cost of a full Int; arithmetic wraps around modulo 2**128.
+type Uint128 [2]uint64
+
+// NewUint128 returns a new Uint128 set to v.
+func NewUint128(v uint64) *Uint128 {
+	return &Uint128{v, 0}
+}
+
+// SetUint64 sets z to v and returns z.
+func (z *Uint128) SetUint64(v uint64) *Uint128 {
+	z[0], z[1] = v, 0
+	return z
+}
+
+// Set sets z to x and returns z.
+func (z *Uint128) Set(x *Uint128) *Uint128 {
+	*z = *x
+	return z
+}
+
+// SetInt sets z to the low 128 bits of x, and returns z and whether x did
+// not fit in 128 bits.
+func (z *Uint128) SetInt(x *Int) (*Uint128, bool) {
+	z[0], z[1] = x[0], x[1]
+	return z, (x[2] | x[3]) != 0
+}
+
+// SetUint128 sets z to x and returns z.
+func (z *Int) SetUint128(x *Uint128) *Int {
+	z[0], z[1], z[2], z[3] = x[0], x[1], 0, 0
+	return z
+}
+
+// IsZero returns true if z == 0.
+func (z *Uint128) IsZero() bool {
+	return (z[0] | z[1]) == 0
+}
+
+// Eq returns true if z == x.
+func (z *Uint128) Eq(x *Uint128) bool {
+	return *z == *x
+}
+
+// Lt returns true if z < x.
+func (z *Uint128) Lt(x *Uint128) bool {
+	_, borrow := bits.Sub64(z[0], x[0], 0)
+	_, borrow = bits.Sub64(z[1], x[1], borrow)
+	return borrow != 0
+}
+
+// Cmp compares z and x and returns -1 if z < x, 0 if z == x and +1 if
+// z > x.
+func (z *Uint128) Cmp(x *Uint128) int {
+	switch {
+	case z.Lt(x):
+		return -1
+	case *z == *x:
+		return 0
+	}
+	return 1
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Uint128) Add(x, y *Uint128) *Uint128 {
+	z.AddOverflow(x, y)
+	return z
+}
+
+// AddOverflow sets z to the sum x+y, and returns z and whether overflow
+// occurred.
+func (z *Uint128) AddOverflow(x, y *Uint128) (*Uint128, bool) {
+	var carry uint64
+	z[0], carry = bits.Add64(x[0], y[0], 0)
+	z[1], carry = bits.Add64(x[1], y[1], carry)
+	return z, carry != 0
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Uint128) Sub(x, y *Uint128) *Uint128 {
+	z.SubOverflow(x, y)
+	return z
+}
+
+// SubOverflow sets z to the difference x-y, and returns z and whether the
+// operation underflowed.
+func (z *Uint128) SubOverflow(x, y *Uint128) (*Uint128, bool) {
+	var borrow uint64
+	z[0], borrow = bits.Sub64(x[0], y[0], 0)
+	z[1], borrow = bits.Sub64(x[1], y[1], borrow)
+	return z, borrow != 0
+}
+
+// Mul sets z to the product x*y mod 2**128 and returns z.
+func (z *Uint128) Mul(x, y *Uint128) *Uint128 {
+	hi, lo := bits.Mul64(x[0], y[0])
+	z[0], z[1] = lo, hi+x[0]*y[1]+x[1]*y[0]
+	return z
+}
+
+// MulFull128 sets z to the full 256-bit product x*y, and returns z.
+func (z *Int) MulFull128(x, y *Uint128) *Int {
+	h00, l00 := bits.Mul64(x[0], y[0])
+	h01, l01 := bits.Mul64(x[0], y[1])
+	h10, l10 := bits.Mul64(x[1], y[0])
+	h11, l11 := bits.Mul64(x[1], y[1])
+
+	var c, c2 uint64
+	z[0] = l00
+	z[1], c = bits.Add64(h00, l01, 0)
+	z[1], c2 = bits.Add64(z[1], l10, 0)
+	z[2], c = bits.Add64(h01, h10, c)
+	z[3] = h11 + c
+	z[2], c = bits.Add64(z[2], l11, c2)
+	z[3] += c
+	return z
+}
+
+// Div sets z to the quotient x/y and returns z.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Uint128) Div(x, y *Uint128) *Uint128 {
+	q, _ := divmod128(x, y)
+	*z = q
+	return z
+}
+
+// Mod sets z to the remainder x%y and returns z.
+// If y == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Uint128) Mod(x, y *Uint128) *Uint128 {
+	_, r := divmod128(x, y)
+	*z = r
+	return z
+}
+
+// divmod128 returns the quotient and remainder of x/y, both 0 if y == 0.
+func divmod128(x, y *Uint128) (q, r Uint128) {
+	if y[1] == 0 {
+		if y[0] == 0 {
+			return q, r
+		}
+		// A two-step long division by a single word.
+		var rem uint64
+		if x[1] < y[0] {
+			q[0], rem = bits.Div64(x[1], x[0], y[0])
+		} else {
+			q[1], rem = x[1]/y[0], x[1]%y[0]
+			q[0], rem = bits.Div64(rem, x[0], y[0])
+		}
+		return q, Uint128{rem, 0}
+	}
+	// The quotient fits in a word. Estimate it from the top 64 bits of the
+	// normalized divisor; the estimate is at most one too large after the
+	// decrement below, and one final correction fixes it.
+	n := uint(bits.LeadingZeros64(y[1]))
+	y1 := y[1]<<n | y[0]>>(64-n)
+	x1 := Uint128{x[0]>>1 | x[1]<<63, x[1] >> 1}
+	tq, _ := bits.Div64(x1[1], x1[0], y1)
+	tq >>= 63 - n
+	if tq != 0 {
+		tq--
+	}
+	q[0] = tq
+	r.Sub(x, new(Uint128).Mul(&q, y))
+	if !r.Lt(y) {
+		q[0]++
+		r.Sub(&r, y)
+	}
+	return q, r
+}
+
+// ToBig returns a big.Int version of z.
+func (z *Uint128) ToBig() *big.Int {
+	return new(Int).SetUint128(z).ToBig()
+}
+
+// String returns the hex encoding of z.
+func (z *Uint128) String() string {
+	return new(Int).SetUint128(z).Hex()
+}
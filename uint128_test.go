@@ -0,0 +1,112 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var bigtt128 = new(big.Int).Lsh(big.NewInt(1), 128)
+
+func uint128Values() []Uint128 {
+	vals := []Uint128{
+		{}, {1, 0}, {2, 0}, {^uint64(0), 0}, {0, 1}, {1, 1},
+		{^uint64(0), ^uint64(0)}, {0, 1 << 63}, {^uint64(0), 1<<63 - 1},
+		{3, 0x8000000000000001}, {0, ^uint64(0)},
+	}
+	for i := 0; i < 40; i++ {
+		_, f, _ := randNums()
+		f.Rsh(f, uint(i*3))
+		vals = append(vals, Uint128{f[0], f[1]})
+	}
+	return vals
+}
+
+func TestUint128Arith(t *testing.T) {
+	vals := uint128Values()
+	for i := range vals {
+		x := &vals[i]
+		bx := x.ToBig()
+		for j := range vals {
+			y := &vals[j]
+			by := y.ToBig()
+			msg := fmt.Sprintf("(%v, %v)", x, y)
+			for _, op := range []struct {
+				name   string
+				native func(z, x, y *Uint128) *Uint128
+				big    func(z, x, y *big.Int) *big.Int
+			}{
+				{"Add", (*Uint128).Add, (*big.Int).Add},
+				{"Sub", (*Uint128).Sub, (*big.Int).Sub},
+				{"Mul", (*Uint128).Mul, (*big.Int).Mul},
+				{"Div", (*Uint128).Div, func(z, x, y *big.Int) *big.Int {
+					if y.Sign() == 0 {
+						return z.SetUint64(0)
+					}
+					return z.Quo(x, y)
+				}},
+				{"Mod", (*Uint128).Mod, func(z, x, y *big.Int) *big.Int {
+					if y.Sign() == 0 {
+						return z.SetUint64(0)
+					}
+					return z.Rem(x, y)
+				}},
+			} {
+				want := op.big(new(big.Int), bx, by)
+				want.Mod(want, bigtt128)
+				if got := op.native(new(Uint128), x, y).ToBig(); got.Cmp(want) != 0 {
+					t.Fatalf("%s%s: got %x, want %x", op.name, msg, got, want)
+				}
+			}
+			sum := new(big.Int).Add(bx, by)
+			if _, overflow := new(Uint128).AddOverflow(x, y); overflow != (sum.Cmp(bigtt128) >= 0) {
+				t.Fatalf("AddOverflow%s: got overflow %v", msg, overflow)
+			}
+			if _, underflow := new(Uint128).SubOverflow(x, y); underflow != (bx.Cmp(by) < 0) {
+				t.Fatalf("SubOverflow%s: got underflow %v", msg, underflow)
+			}
+			if got, want := x.Cmp(y), bx.Cmp(by); got != want {
+				t.Fatalf("Cmp%s: got %d, want %d", msg, got, want)
+			}
+			if got, want := x.Eq(y), bx.Cmp(by) == 0; got != want {
+				t.Fatalf("Eq%s: got %v, want %v", msg, got, want)
+			}
+			requireEq(t, new(big.Int).Mul(bx, by), new(Int).MulFull128(x, y), "MulFull128"+msg)
+		}
+	}
+}
+
+func TestUint128Conversions(t *testing.T) {
+	x, overflow := new(Uint128).SetInt(&Int{1, 2, 0, 0})
+	if overflow || *x != (Uint128{1, 2}) {
+		t.Errorf("SetInt: got %v, %v", x, overflow)
+	}
+	if _, overflow := new(Uint128).SetInt(&Int{1, 2, 3, 0}); !overflow {
+		t.Errorf("SetInt: no overflow for a 192-bit value")
+	}
+	if got := new(Int).SetUint128(&Uint128{5, 6}); *got != (Int{5, 6, 0, 0}) {
+		t.Errorf("SetUint128: got %x", got)
+	}
+	if got := NewUint128(7).String(); got != "0x7" {
+		t.Errorf("String: got %q", got)
+	}
+	if !new(Uint128).IsZero() || NewUint128(1).IsZero() {
+		t.Errorf("IsZero failed")
+	}
+}
+
+func BenchmarkUint128Div(b *testing.B) {
+	x := Uint128{0x0123456789abcdef, 0xfedcba9876543210}
+	for _, y := range []Uint128{{0x1234567, 0}, {0x1234567, 0x89}} {
+		b.Run(fmt.Sprintf("hi=%d", y[1]), func(b *testing.B) {
+			var z Uint128
+			for i := 0; i < b.N; i++ {
+				z.Div(&x, &y)
+			}
+		})
+	}
+}
@@ -13,7 +13,9 @@ import (
 )
 
 // Int is represented as an array of 4 uint64, in little-endian order,
-// so that Int[3] is the most significant, and Int[0] is the least significant
+// so that Int[3] is the most significant, and Int[0] is the least significant.
+// Methods of the form z.Op(x, y) store the result in z and return it; the
+// receiver may alias any of the operands, and operands may alias each other.
 type Int [4]uint64
 
 // NewInt returns a new initialized Int.
@@ -193,11 +195,16 @@ func (z *Int) AddOverflow(x, y *Int) (*Int, bool) {
 }
 
 // AddMod sets z to the sum ( x+y ) mod m, and returns z.
+// If both x and y are already reduced modulo m, this takes a single
+// conditional subtraction instead of a division.
 // If m == 0, z is set to 0 (OBS: differs from the big.Int)
 func (z *Int) AddMod(x, y, m *Int) *Int {
 	if m.IsZero() {
 		return z.Clear()
 	}
+	if x.Lt(m) && y.Lt(m) {
+		return z.addModReduced(x, y, m)
+	}
 	if z == m { // z is an alias for m  // TODO: Understand why needed and add tests for all "division" methods.
 		m = m.Clone()
 	}
@@ -210,6 +217,100 @@ func (z *Int) AddMod(x, y, m *Int) *Int {
 	return z.Mod(z, m)
 }
 
+// addModReduced sets z to (x + y) mod m, for x, y < m, and returns z.
+func (z *Int) addModReduced(x, y, m *Int) *Int {
+	var (
+		sum, diff     Int
+		carry, borrow uint64
+	)
+	sum[0], carry = bits.Add64(x[0], y[0], 0)
+	sum[1], carry = bits.Add64(x[1], y[1], carry)
+	sum[2], carry = bits.Add64(x[2], y[2], carry)
+	sum[3], carry = bits.Add64(x[3], y[3], carry)
+	// The 257-bit sum is below 2m, so subtracting m once is enough.
+	diff[0], borrow = bits.Sub64(sum[0], m[0], 0)
+	diff[1], borrow = bits.Sub64(sum[1], m[1], borrow)
+	diff[2], borrow = bits.Sub64(sum[2], m[2], borrow)
+	diff[3], borrow = bits.Sub64(sum[3], m[3], borrow)
+	if carry == 0 && borrow != 0 {
+		return z.Set(&sum)
+	}
+	return z.Set(&diff)
+}
+
+// SubMod sets z to the difference ( x-y ) mod m, and returns z.
+// If both x and y are already reduced modulo m, this takes a single
+// conditional addition instead of a division.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) SubMod(x, y, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	a, b, mod := *x, *y, *m
+	if !a.Lt(&mod) {
+		a.Mod(&a, &mod)
+	}
+	if !b.Lt(&mod) {
+		b.Mod(&b, &mod)
+	}
+	return z.subModReduced(&a, &b, &mod)
+}
+
+// NegMod sets z to -x mod m, and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) NegMod(x, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	a, mod := *x, *m
+	if !a.Lt(&mod) {
+		a.Mod(&a, &mod)
+	}
+	if a.IsZero() {
+		return z.Clear()
+	}
+	return z.Sub(&mod, &a)
+}
+
+// DoubleMod sets z to 2*x mod m, and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) DoubleMod(x, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	a := *x
+	if !a.Lt(m) {
+		a.Mod(&a, m)
+	}
+	return z.addModReduced(&a, &a, m)
+}
+
+// HalveMod sets z to x/2 mod m, i.e. x times the inverse of 2, for odd m,
+// and returns z.
+// If m is even, including m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) HalveMod(x, m *Int) *Int {
+	if m[0]&1 == 0 {
+		return z.Clear()
+	}
+	a := *x
+	if !a.Lt(m) {
+		a.Mod(&a, m)
+	}
+	// If a is odd, a+m is even and a/2 = (a+m)/2 mod m. The sum may carry
+	// into bit 256, which is shifted back in.
+	mask := -(a[0] & 1)
+	var carry uint64
+	a[0], carry = bits.Add64(a[0], m[0]&mask, 0)
+	a[1], carry = bits.Add64(a[1], m[1]&mask, carry)
+	a[2], carry = bits.Add64(a[2], m[2]&mask, carry)
+	a[3], carry = bits.Add64(a[3], m[3]&mask, carry)
+	z[0] = a[0]>>1 | a[1]<<63
+	z[1] = a[1]>>1 | a[2]<<63
+	z[2] = a[2]>>1 | a[3]<<63
+	z[3] = a[3]>>1 | carry<<63
+	return z
+}
+
 // AddUint64 sets z to x + y, where y is a uint64, and returns z
 func (z *Int) AddUint64(x *Int, y uint64) *Int {
 	var carry uint64
@@ -312,6 +413,85 @@ func umul(x, y *Int) [8]uint64 {
 	return res
 }
 
+// MulDivOverflow sets z to floor(x*y / d) and returns z, and whether the
+// quotient overflowed 256 bits, in which case z holds its low 256 bits. The
+// product is computed in full 512 bits, so only the quotient can overflow.
+// If d == 0, z is set to 0 and overflow is false (OBS: differs from the big.Int)
+func (z *Int) MulDivOverflow(x, y, d *Int) (*Int, bool) {
+	if x.IsZero() || y.IsZero() || d.IsZero() {
+		return z.Clear(), false
+	}
+	p := umul(x, y)
+	if p[4]|p[5]|p[6]|p[7] == 0 {
+		return z.Div(&Int{p[0], p[1], p[2], p[3]}, d), false
+	}
+	var quot [8]uint64
+	udivrem(quot[:], p[:], d)
+	copy(z[:], quot[:4])
+	return z, (quot[4] | quot[5] | quot[6] | quot[7]) != 0
+}
+
+// MulFull returns the full 512-bit product of x and y, as its high and low
+// 256-bit halves: x*y = hi*2**256 + lo.
+func MulFull(x, y *Int) (hi, lo Int) {
+	p := umul(x, y)
+	copy(lo[:], p[:4])
+	copy(hi[:], p[4:])
+	return hi, lo
+}
+
+// MulFullWords sets res to the full 512-bit product of x and y, as eight
+// little-endian 64-bit words.
+func MulFullWords(res *[8]uint64, x, y *Int) {
+	*res = umul(x, y)
+}
+
+// usqr computes the full 256 -> 512 squaring of x. Each cross product
+// x[i]*x[j], i < j, is computed once and doubled, so it takes 10 word
+// multiplications instead of the 16 of umul.
+func usqr(x *Int) [8]uint64 {
+	var (
+		res                        [8]uint64
+		carry                      uint64
+		r1, r2, r3, r4, r5, r6, r7 uint64
+	)
+
+	// Cross products.
+	carry, r1 = bits.Mul64(x[0], x[1])
+	carry, r2 = umulHop(carry, x[0], x[2])
+	r4, r3 = umulHop(carry, x[0], x[3])
+
+	carry, r3 = umulHop(r3, x[1], x[2])
+	r5, r4 = umulStep(r4, x[1], x[3], carry)
+
+	r6, r5 = umulHop(r5, x[2], x[3])
+
+	// Double them.
+	r7 = r6 >> 63
+	r6 = r6<<1 | r5>>63
+	r5 = r5<<1 | r4>>63
+	r4 = r4<<1 | r3>>63
+	r3 = r3<<1 | r2>>63
+	r2 = r2<<1 | r1>>63
+	r1 = r1 << 1
+
+	// Add the squares on the diagonal.
+	var hi, lo uint64
+	hi, res[0] = bits.Mul64(x[0], x[0])
+	res[1], carry = bits.Add64(r1, hi, 0)
+	hi, lo = bits.Mul64(x[1], x[1])
+	res[2], carry = bits.Add64(r2, lo, carry)
+	res[3], carry = bits.Add64(r3, hi, carry)
+	hi, lo = bits.Mul64(x[2], x[2])
+	res[4], carry = bits.Add64(r4, lo, carry)
+	res[5], carry = bits.Add64(r5, hi, carry)
+	hi, lo = bits.Mul64(x[3], x[3])
+	res[6], carry = bits.Add64(r6, lo, carry)
+	res[7], _ = bits.Add64(r7, hi, carry)
+
+	return res
+}
+
 // Mul sets z to the product x*y
 func (z *Int) Mul(x, y *Int) *Int {
 	var (
@@ -344,6 +524,33 @@ func (z *Int) MulOverflow(x, y *Int) (*Int, bool) {
 	return z, (p[4] | p[5] | p[6] | p[7]) != 0
 }
 
+// AddSat sets z to the sum x+y, clamped to 2**256-1 on overflow, and
+// returns z.
+func (z *Int) AddSat(x, y *Int) *Int {
+	if _, overflow := z.AddOverflow(x, y); overflow {
+		return z.SetAllOne()
+	}
+	return z
+}
+
+// SubSat sets z to the difference x-y, clamped to 0 on underflow, and
+// returns z.
+func (z *Int) SubSat(x, y *Int) *Int {
+	if _, underflow := z.SubOverflow(x, y); underflow {
+		return z.Clear()
+	}
+	return z
+}
+
+// MulSat sets z to the product x*y, clamped to 2**256-1 on overflow, and
+// returns z.
+func (z *Int) MulSat(x, y *Int) *Int {
+	if _, overflow := z.MulOverflow(x, y); overflow {
+		return z.SetAllOne()
+	}
+	return z
+}
+
 func (z *Int) squared() {
 	var (
 		res                    Int
@@ -577,12 +784,22 @@ func (z *Int) SMod(x, y *Int) *Int {
 }
 
 // MulMod calculates the modulo-m multiplication of x and y and
-// returns z.
+// returns z. If m is pinned with RegisterFixedModulus, its precomputed
+// reduction is used.
 // If m == 0, z is set to 0 (OBS: differs from the big.Int)
 func (z *Int) MulMod(x, y, m *Int) *Int {
 	if x.IsZero() || y.IsZero() || m.IsZero() {
 		return z.Clear()
 	}
+	if mod := fixedModulus(m); mod != nil {
+		return mod.MulMod(z, x, y)
+	}
+	// If the bit lengths guarantee the product fits in 256 bits, skip the
+	// full 512-bit multiplication and use Mod().
+	if x.BitLen()+y.BitLen() <= 256 {
+		var p Int
+		return z.Mod(p.Mul(x, y), m)
+	}
 	p := umul(x, y)
 	var (
 		pl Int
@@ -601,6 +818,38 @@ func (z *Int) MulMod(x, y, m *Int) *Int {
 	return z.Set(&rem)
 }
 
+// MulAddMod calculates (a*b + c) mod m and returns z. The full 512-bit
+// intermediate is reduced once, which saves a reduction compared to a
+// MulMod followed by an AddMod.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) MulAddMod(a, b, c, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	r := mulAddMod(a, b, c, m)
+	return z.Set(&r)
+}
+
+// SqrMod calculates the modulo-m squaring of x and returns z.
+// It is equivalent to MulMod(x, x, m), but faster.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int)
+func (z *Int) SqrMod(x, m *Int) *Int {
+	if x.IsZero() || m.IsZero() {
+		return z.Clear()
+	}
+	if mod := fixedModulus(m); mod != nil {
+		return mod.SqrMod(z, x)
+	}
+	if x.BitLen() <= 128 {
+		var p Int
+		return z.Mod(p.Mul(x, x), m)
+	}
+	p := usqr(x)
+	var quot [8]uint64
+	rem := udivrem(quot[:], p[:], m)
+	return z.Set(&rem)
+}
+
 // Abs interprets x as a two's complement signed number,
 // and sets z to the absolute value
 //   Abs(0)        = 0
@@ -1116,6 +1365,37 @@ func (z *Int) Exp(base, exponent *Int) *Int {
 	return z.Set(&res)
 }
 
+// Pow sets z = base**exponent mod 2**256, and returns z. It is the wrapping
+// power of the EVM EXP opcode, and equivalent to Exp.
+func (z *Int) Pow(base, exponent *Int) *Int {
+	return z.Exp(base, exponent)
+}
+
+// PowOverflow sets z = base**exponent mod 2**256, and returns z and whether
+// the true result overflowed 256 bits.
+func (z *Int) PowOverflow(base, exponent *Int) (*Int, bool) {
+	var (
+		res        = Int{1, 0, 0, 0}
+		multiplier = *base
+		expBitLen  = exponent.BitLen()
+		overflow   bool
+		// multOverflow is set once the true value of multiplier no longer
+		// fits; it only matters if the multiplier is used afterwards.
+		multOverflow bool
+	)
+	for i := 0; i < expBitLen; i++ {
+		if exponent[i/64]&(1<<(uint(i)%64)) != 0 {
+			_, o := res.MulOverflow(&res, &multiplier)
+			overflow = overflow || o || multOverflow
+		}
+		if i+1 < expBitLen {
+			_, o := multiplier.MulOverflow(&multiplier, &multiplier)
+			multOverflow = multOverflow || o
+		}
+	}
+	return z.Set(&res), overflow
+}
+
 // ExtendSign extends length of two’s complement signed integer,
 // sets z to
 //  - x if byteNum > 31
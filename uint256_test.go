@@ -86,6 +86,9 @@ var (
 		{"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "1"},
 		{"0xffffffffffffffffffffffffffffffff", "0xffffffffffffffffffffffffffffffff", "0xfffffffffffffffffffffffffffffffe00000000000000000000000000000002"},
 		{"0xffffffffffffffffffffffffffffffff", "0xffffffffffffffffffffffffffffffff", "0xfffffffffffffffffffffffffffffffe00000000000000000000000000000001"},
+		{"0x100000000000000000000000000000000", "0x80000000000000000000000000000000", "0x7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff3"},
+		{"0x1ffffffffffffffffffffffffffffffff", "0xffffffffffffffffffffffffffffffff", "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1"},
+		{"0xffffffffffffffff", "0x1ffffffffffffffffffffffffffffffffffffffffffffffff", "0x1000000000000000000000000000000000000000000000000000000000000001"},
 	}
 )
 
@@ -279,6 +282,56 @@ func TestRandomMul(t *testing.T) {
 	)
 }
 
+// bigSat clamps b to the range of Int.
+func bigSat(b *big.Int) *big.Int {
+	if b.Sign() < 0 {
+		return b.SetUint64(0)
+	}
+	if b.Cmp(bigtt256) >= 0 {
+		return b.Sub(bigtt256, big.NewInt(1))
+	}
+	return b
+}
+
+func TestRandomSaturating(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		native func(a, b, c *Int)
+		big    func(a, b, c *big.Int)
+	}{
+		{"AddSat", func(a, b, c *Int) { a.AddSat(b, c) }, func(a, b, c *big.Int) { bigSat(a.Add(b, c)) }},
+		{"SubSat", func(a, b, c *Int) { a.SubSat(b, c) }, func(a, b, c *big.Int) { bigSat(a.Sub(b, c)) }},
+		{"MulSat", func(a, b, c *Int) { a.MulSat(b, c) }, func(a, b, c *big.Int) { bigSat(a.Mul(b, c)) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testRandomOp(t, tc.native, tc.big)
+		})
+	}
+}
+
+func TestSaturatingEdges(t *testing.T) {
+	max := new(Int).SetAllOne()
+	one := NewInt(1)
+	if z := new(Int).AddSat(max, one); !z.Eq(max) {
+		t.Errorf("AddSat(max, 1): got %x", z)
+	}
+	if z := new(Int).AddSat(new(Int).Sub(max, one), one); !z.Eq(max) {
+		t.Errorf("AddSat(max-1, 1): got %x", z)
+	}
+	if z := new(Int).SubSat(one, NewInt(2)); !z.IsZero() {
+		t.Errorf("SubSat(1, 2): got %x", z)
+	}
+	if z := new(Int).SubSat(max, max); !z.IsZero() {
+		t.Errorf("SubSat(max, max): got %x", z)
+	}
+	if z := new(Int).MulSat(new(Int).Lsh(one, 128), new(Int).Lsh(one, 128)); !z.Eq(max) {
+		t.Errorf("MulSat(2**128, 2**128): got %x", z)
+	}
+	if z := new(Int).MulSat(new(Int).Lsh(one, 128), new(Int).Lsh(one, 127)); !z.Eq(new(Int).Lsh(one, 255)) {
+		t.Errorf("MulSat(2**128, 2**127): got %x", z)
+	}
+}
+
 func TestRandomMulOverflow(t *testing.T) {
 	for i := 0; i < 10000; i++ {
 		b, f1, err := randNums()
@@ -769,6 +822,40 @@ func TestRandomExp(t *testing.T) {
 	}
 }
 
+func TestPowOverflow(t *testing.T) {
+	check := func(base, exp *Int) {
+		t.Helper()
+		want := new(big.Int).Exp(base.ToBig(), exp.ToBig(), bigtt256)
+		// The true power overflows unless the base is 0 or 1, or it fits in
+		// 256 bits; exponents above 256 overflow for any base of 2 or more.
+		wantOverflow := false
+		if base.GtUint64(1) {
+			wantOverflow = exp.GtUint64(256) ||
+				new(big.Int).Exp(base.ToBig(), exp.ToBig(), nil).Cmp(bigtt256) >= 0
+		}
+		got, overflow := new(Int).PowOverflow(base, exp)
+		msg := fmt.Sprintf("PowOverflow(%x, %x)", base, exp)
+		if overflow != wantOverflow {
+			t.Fatalf("%s: got overflow %v, want %v", msg, overflow, wantOverflow)
+		}
+		requireEq(t, want, got, msg)
+		requireEq(t, want, new(Int).Pow(base, exp), "Pow")
+	}
+	max := new(Int).SetAllOne()
+	for _, base := range []*Int{NewInt(0), NewInt(1), NewInt(2), NewInt(3), NewInt(255), NewInt(256),
+		new(Int).Lsh(NewInt(1), 128), new(Int).Sub(new(Int).Lsh(NewInt(1), 128), NewInt(1)), max} {
+		for _, e := range []uint64{0, 1, 2, 3, 31, 32, 33, 85, 161, 162, 255, 256, 257, 1 << 40} {
+			check(base, NewInt(e))
+		}
+		check(base, max)
+	}
+	for i := 0; i < 1000; i++ {
+		_, base, _ := randNums()
+		base.Rsh(base, uint(i%256))
+		check(base, NewInt(uint64(i%300)))
+	}
+}
+
 func TestUnOp(t *testing.T) {
 	proc := func(t *testing.T, op func(a, b *Int) *Int, bigOp func(a, b *big.Int) *big.Int) {
 		for i := 0; i < len(unTestCases); i++ {
@@ -886,6 +973,42 @@ func TestBinOp(t *testing.T) {
 			return z.Mod(x, y)
 		})
 	})
+	t.Run("NegMod", func(t *testing.T) {
+		proc(t, (*Int).NegMod, func(z, x, y *big.Int) *big.Int {
+			if y.Sign() == 0 {
+				return z.SetUint64(0)
+			}
+			return z.Mod(z.Neg(x), y)
+		})
+	})
+	t.Run("DoubleMod", func(t *testing.T) {
+		proc(t, (*Int).DoubleMod, func(z, x, y *big.Int) *big.Int {
+			if y.Sign() == 0 {
+				return z.SetUint64(0)
+			}
+			return z.Mod(z.Lsh(x, 1), y)
+		})
+	})
+	t.Run("HalveMod", func(t *testing.T) {
+		proc(t, (*Int).HalveMod, func(z, x, y *big.Int) *big.Int {
+			if y.Bit(0) == 0 {
+				return z.SetUint64(0)
+			}
+			inv2 := new(big.Int).ModInverse(big.NewInt(2), y)
+			if inv2 == nil { // y == 1
+				return z.SetUint64(0)
+			}
+			return z.Mod(z.Mul(x, inv2), y)
+		})
+	})
+	t.Run("SqrMod", func(t *testing.T) {
+		proc(t, (*Int).SqrMod, func(z, x, y *big.Int) *big.Int {
+			if y.Sign() == 0 {
+				return z.SetUint64(0)
+			}
+			return mulMod(z, x, x, y)
+		})
+	})
 	t.Run("SDiv", func(t *testing.T) { proc(t, (*Int).SDiv, SDiv) })
 	t.Run("SMod", func(t *testing.T) { proc(t, (*Int).SMod, SMod) })
 	t.Run("Exp", func(t *testing.T) { proc(t, (*Int).Exp, Exp) })
@@ -991,6 +1114,14 @@ func TestTernOp(t *testing.T) {
 			return addMod(z, x, y, m)
 		})
 	})
+	t.Run("SubMod", func(t *testing.T) {
+		proc(t, (*Int).SubMod, func(z, x, y, m *big.Int) *big.Int {
+			if m.Sign() == 0 {
+				return z.SetUint64(0)
+			}
+			return z.Mod(z.Sub(x, y), m)
+		})
+	})
 	t.Run("MulMod", func(t *testing.T) {
 		proc(t, (*Int).MulMod, func(z, x, y, m *big.Int) *big.Int {
 			if m.Sign() == 0 {
@@ -1287,3 +1418,105 @@ func TestByte32Representation(t *testing.T) {
 		}
 	}
 }
+
+func TestUsqr(t *testing.T) {
+	check := func(x *Int) {
+		if got, want := usqr(x), umul(x, x); got != want {
+			t.Fatalf("usqr(%x): got %x, want %x", x, got, want)
+		}
+	}
+	check(new(Int))
+	check(new(Int).SetAllOne())
+	for i := 0; i < 10000; i++ {
+		_, x, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(x)
+	}
+}
+
+func TestMulFull(t *testing.T) {
+	check := func(bx, by *big.Int, x, y *Int) {
+		t.Helper()
+		want := new(big.Int).Mul(bx, by)
+		hi, lo := MulFull(x, y)
+		got := new(big.Int).Lsh(hi.ToBig(), 256)
+		got.Add(got, lo.ToBig())
+		if got.Cmp(want) != 0 {
+			t.Fatalf("MulFull(%x, %x): got %x, want %x", x, y, got, want)
+		}
+		var words [8]uint64
+		MulFullWords(&words, x, y)
+		if words != umul(x, y) || hi != (Int{words[4], words[5], words[6], words[7]}) {
+			t.Fatalf("MulFullWords(%x, %x): got %x", x, y, words)
+		}
+	}
+	max := new(Int).SetAllOne()
+	check(max.ToBig(), max.ToBig(), max, max)
+	for i := 0; i < 1000; i++ {
+		bx, x, err := randNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		by, y, err := randHighNums()
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(bx, by, x, y)
+	}
+}
+
+func TestMulDivOverflow(t *testing.T) {
+	check := func(x, y, d *Int) {
+		t.Helper()
+		want := new(big.Int)
+		if !d.IsZero() {
+			want.Mul(x.ToBig(), y.ToBig())
+			want.Quo(want, d.ToBig())
+		}
+		wantOverflow := want.Cmp(bigtt256) >= 0
+		want.Mod(want, bigtt256)
+		got, overflow := new(Int).MulDivOverflow(x, y, d)
+		if overflow != wantOverflow {
+			t.Fatalf("MulDivOverflow(%x, %x, %x): got overflow %v, want %v", x, y, d, overflow, wantOverflow)
+		}
+		requireEq(t, want, got, fmt.Sprintf("MulDivOverflow(%x, %x, %x)", x, y, d))
+	}
+	max := new(Int).SetAllOne()
+	for _, d := range []*Int{new(Int), NewInt(1), NewInt(3), max, new(Int).Sub(max, NewInt(1))} {
+		check(max, max, d)
+		check(max, NewInt(7), d)
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randHighNums()
+		_, d, _ := randNums()
+		check(x, y, d)
+	}
+}
+
+func TestMulAddMod(t *testing.T) {
+	max := new(Int).SetAllOne()
+	check := func(a, b, c, m *Int) {
+		t.Helper()
+		want := new(big.Int).Mul(a.ToBig(), b.ToBig())
+		want.Add(want, c.ToBig())
+		if m.IsZero() {
+			want.SetUint64(0)
+		} else {
+			want.Mod(want, m.ToBig())
+		}
+		requireEq(t, want, new(Int).MulAddMod(a, b, c, m), fmt.Sprintf("MulAddMod(%x, %x, %x, %x)", a, b, c, m))
+	}
+	check(max, max, max, max)
+	check(max, max, max, NewInt(7))
+	check(max, max, max, new(Int))
+	for i := 0; i < 1000; i++ {
+		_, a, _ := randHighNums()
+		_, b, _ := randHighNums()
+		_, c, _ := randHighNums()
+		_, m, _ := randNums()
+		check(a, b, c, m)
+	}
+}
@@ -0,0 +1,130 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+//go:generate go run ./cmd/widthgen -bits 160 -o uint160.go
+//go:generate go run ./cmd/widthgen -bits 192 -o uint192.go
+//go:generate go run ./cmd/widthgen -bits 320 -o uint320.go
+//go:generate go run ./cmd/widthgen -bits 384 -o uint384.go
+
+import "math/bits"
+
+// The fixed-width types Uint160, Uint192, Uint320 and Uint384 are generated
+// by cmd/widthgen. Their methods are thin wrappers around the word-slice
+// helpers below, so that all widths share one implementation of each
+// algorithm. The helpers support operands of up to 16 words, enough for the
+// double-width products reduced by the modular methods of 512-bit types.
+
+// mulWords sets res to the full product x*y. len(res) must be
+// len(x)+len(y), and res must not alias x or y.
+func mulWords(res, x, y []uint64) {
+	for i := range res {
+		res[i] = 0
+	}
+	for j := range y {
+		var carry uint64
+		for i := range x {
+			hi, lo := bits.Mul64(x[i], y[j])
+			lo, c := bits.Add64(lo, res[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			res[i+j], carry = lo, hi
+		}
+		res[j+len(x)] = carry
+	}
+}
+
+// udivremWords sets quot to u/d and rem to u%d, where len(quot) == len(u)
+// and len(rem) == len(d). d must be nonzero, and quot and rem must not alias
+// u or d.
+func udivremWords(quot, rem, u, d []uint64) {
+	for i := range quot {
+		quot[i] = 0
+	}
+	for i := range rem {
+		rem[i] = 0
+	}
+	dLen := len(d)
+	for d[dLen-1] == 0 {
+		dLen--
+	}
+	uLen := len(u)
+	for uLen > 0 && u[uLen-1] == 0 {
+		uLen--
+	}
+	if uLen < dLen {
+		copy(rem, u)
+		return
+	}
+
+	// Normalize, as in udivrem.
+	shift := uint(bits.LeadingZeros64(d[dLen-1]))
+	var dnStorage [16]uint64
+	dn := dnStorage[:dLen]
+	for i := dLen - 1; i > 0; i-- {
+		dn[i] = (d[i] << shift) | (d[i-1] >> (64 - shift))
+	}
+	dn[0] = d[0] << shift
+
+	var unStorage [17]uint64
+	un := unStorage[:uLen+1]
+	un[uLen] = u[uLen-1] >> (64 - shift)
+	for i := uLen - 1; i > 0; i-- {
+		un[i] = (u[i] << shift) | (u[i-1] >> (64 - shift))
+	}
+	un[0] = u[0] << shift
+
+	if dLen == 1 {
+		rem[0] = udivremBy1(quot, un, dn[0]) >> shift
+		return
+	}
+	udivremKnuth(quot, un, dn)
+	for i := 0; i < dLen-1; i++ {
+		rem[i] = (un[i] >> shift) | (un[i+1] << (64 - shift))
+	}
+	rem[dLen-1] = un[dLen-1] >> shift
+}
+
+// lshWords sets z to x << n, truncated to len(z) == len(x) words. z may
+// alias x.
+func lshWords(z, x []uint64, n uint) {
+	w, s := int(n/64), n%64
+	for i := len(z) - 1; i >= 0; i-- {
+		var v uint64
+		if i-w >= 0 {
+			v = x[i-w] << s
+			if i-w-1 >= 0 {
+				v |= x[i-w-1] >> (64 - s)
+			}
+		}
+		z[i] = v
+	}
+}
+
+// rshWords sets z to x >> n, where len(z) == len(x). z may alias x.
+func rshWords(z, x []uint64, n uint) {
+	w, s := int(n/64), n%64
+	for i := range z {
+		var v uint64
+		if i+w < len(x) {
+			v = x[i+w] >> s
+			if i+w+1 < len(x) {
+				v |= x[i+w+1] << (64 - s)
+			}
+		}
+		z[i] = v
+	}
+}
+
+// bitLenWords returns the number of bits required to represent x.
+func bitLenWords(x []uint64) int {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] != 0 {
+			return i*64 + bits.Len64(x[i])
+		}
+	}
+	return 0
+}
@@ -0,0 +1,217 @@
+// uint256: Fixed size 256-bit math library
+// Copyright 2020 uint256 Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package uint256
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// TestWidths checks the generated fixed-width types against big.Int. The
+// types share their method set, so the test drives them by reflection.
+func TestWidths(t *testing.T) {
+	for _, tc := range []struct {
+		bits uint
+		typ  reflect.Type
+	}{
+		{160, reflect.TypeOf(Uint160{})},
+		{192, reflect.TypeOf(Uint192{})},
+		{320, reflect.TypeOf(Uint320{})},
+		{384, reflect.TypeOf(Uint384{})},
+	} {
+		t.Run(fmt.Sprint(tc.bits), func(t *testing.T) {
+			testWidth(t, tc.bits, tc.typ)
+		})
+	}
+}
+
+func testWidth(t *testing.T, n uint, typ reflect.Type) {
+	one := big.NewInt(1)
+	mod := new(big.Int).Lsh(one, n)
+	max := new(big.Int).Sub(mod, one)
+	// conv converts b, which must fit, to a new value of the type.
+	conv := func(b *big.Int) reflect.Value {
+		v := reflect.New(typ)
+		if overflow := v.MethodByName("SetFromBig").Call([]reflect.Value{reflect.ValueOf(b)})[0].Bool(); overflow {
+			t.Fatalf("SetFromBig(%x) overflowed", b)
+		}
+		return v
+	}
+	toBig := func(v reflect.Value) *big.Int {
+		return v.MethodByName("ToBig").Call(nil)[0].Interface().(*big.Int)
+	}
+
+	vals := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(3), max,
+		new(big.Int).Lsh(one, n-1), new(big.Int).Lsh(one, 64), new(big.Int).Lsh(one, 128),
+		new(big.Int).Sub(new(big.Int).Lsh(one, 64), one),
+	}
+	for i := 0; i < 24; i++ {
+		b, err := rand.Int(rand.Reader, mod)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals = append(vals, b.Rsh(b, uint(i*16)%n))
+	}
+
+	binOps := []struct {
+		name string
+		big  func(x, y *big.Int) *big.Int
+	}{
+		{"Add", func(x, y *big.Int) *big.Int { return new(big.Int).Add(x, y) }},
+		{"Sub", func(x, y *big.Int) *big.Int { return new(big.Int).Sub(x, y) }},
+		{"Mul", func(x, y *big.Int) *big.Int { return new(big.Int).Mul(x, y) }},
+		{"Div", func(x, y *big.Int) *big.Int {
+			if y.Sign() == 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Quo(x, y)
+		}},
+		{"Mod", func(x, y *big.Int) *big.Int {
+			if y.Sign() == 0 {
+				return new(big.Int)
+			}
+			return new(big.Int).Rem(x, y)
+		}},
+		{"And", func(x, y *big.Int) *big.Int { return new(big.Int).And(x, y) }},
+		{"Or", func(x, y *big.Int) *big.Int { return new(big.Int).Or(x, y) }},
+		{"Xor", func(x, y *big.Int) *big.Int { return new(big.Int).Xor(x, y) }},
+	}
+	for _, bx := range vals {
+		x := conv(bx)
+		if got := toBig(x); got.Cmp(bx) != 0 {
+			t.Fatalf("ToBig(SetFromBig(%x)): got %x", bx, got)
+		}
+		if got := x.MethodByName("BitLen").Call(nil)[0].Int(); got != int64(bx.BitLen()) {
+			t.Fatalf("BitLen(%x): got %d", bx, got)
+		}
+		want := new(big.Int).Xor(bx, max)
+		if got := toBig(reflect.New(typ).MethodByName("Not").Call([]reflect.Value{x})[0]); got.Cmp(want) != 0 {
+			t.Fatalf("Not(%x): got %x, want %x", bx, got, want)
+		}
+		for _, s := range []uint{0, 1, 31, 63, 64, 65, 128, n - 1, n, n + 1} {
+			args := []reflect.Value{x, reflect.ValueOf(s)}
+			want := new(big.Int).Lsh(bx, s)
+			if got := toBig(reflect.New(typ).MethodByName("Lsh").Call(args)[0]); got.Cmp(want.Mod(want, mod)) != 0 {
+				t.Fatalf("Lsh(%x, %d): got %x, want %x", bx, s, got, want)
+			}
+			want.Rsh(bx, s)
+			if got := toBig(reflect.New(typ).MethodByName("Rsh").Call(args)[0]); got.Cmp(want) != 0 {
+				t.Fatalf("Rsh(%x, %d): got %x, want %x", bx, s, got, want)
+			}
+		}
+		b := x.MethodByName("Bytes").Call(nil)[0]
+		bs := make([]byte, b.Len())
+		reflect.Copy(reflect.ValueOf(bs), b)
+		if got := new(big.Int).SetBytes(bs); got.Cmp(bx) != 0 || len(bs) != int(n/8) {
+			t.Fatalf("Bytes(%x): got %x", bx, bs)
+		}
+		if got := toBig(reflect.New(typ).MethodByName("SetBytes").Call([]reflect.Value{reflect.ValueOf(append([]byte{0xff}, bs...))})[0]); got.Cmp(bx) != 0 {
+			t.Fatalf("SetBytes(ff%x): got %x", bs, got)
+		}
+		xi, _ := FromBig(new(big.Int).Mod(bx, bigtt256))
+		res := reflect.New(typ).MethodByName("SetInt").Call([]reflect.Value{reflect.ValueOf(xi)})
+		if got, want := toBig(res[0]), new(big.Int).Mod(xi.ToBig(), mod); got.Cmp(want) != 0 || res[1].Bool() != (xi.ToBig().Cmp(want) != 0) {
+			t.Fatalf("SetInt(%x): got %x, %v", xi, got, res[1].Bool())
+		}
+		res = x.MethodByName("ToInt").Call(nil)
+		if got, want := res[0].Interface().(*Int).ToBig(), new(big.Int).Mod(bx, bigtt256); got.Cmp(want) != 0 || res[1].Bool() != (bx.Cmp(want) != 0) {
+			t.Fatalf("ToInt(%x): got %x, %v", bx, got, res[1].Bool())
+		}
+
+		for _, by := range vals {
+			y := conv(by)
+			msg := fmt.Sprintf("(%x, %x)", bx, by)
+			for _, op := range binOps {
+				want := op.big(bx, by)
+				want.Mod(want, mod)
+				got := toBig(reflect.New(typ).MethodByName(op.name).Call([]reflect.Value{x, y})[0])
+				if got.Cmp(want) != 0 {
+					t.Fatalf("%s%s: got %x, want %x", op.name, msg, got, want)
+				}
+			}
+			for _, op := range []struct {
+				name string
+				want bool
+			}{
+				{"AddOverflow", new(big.Int).Add(bx, by).Cmp(mod) >= 0},
+				{"SubOverflow", bx.Cmp(by) < 0},
+				{"MulOverflow", new(big.Int).Mul(bx, by).Cmp(mod) >= 0},
+			} {
+				res := reflect.New(typ).MethodByName(op.name).Call([]reflect.Value{x, y})
+				if res[1].Bool() != op.want {
+					t.Fatalf("%s%s: got overflow %v", op.name, msg, res[1].Bool())
+				}
+			}
+			if got, want := x.MethodByName("Cmp").Call([]reflect.Value{y})[0].Int(), int64(bx.Cmp(by)); got != want {
+				t.Fatalf("Cmp%s: got %d, want %d", msg, got, want)
+			}
+		}
+	}
+	// The modular methods, against a few moduli; at 384 bits these include
+	// the BLS12-381 base field prime.
+	moduli := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(7), max, vals[len(vals)-1], vals[len(vals)/2]}
+	if n == 384 {
+		p, _ := new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+		moduli = append(moduli, p)
+	}
+	modOps := []struct {
+		name string
+		big  func(x, y, m *big.Int) *big.Int
+	}{
+		{"AddMod", func(x, y, m *big.Int) *big.Int { return new(big.Int).Add(x, y) }},
+		{"MulMod", func(x, y, m *big.Int) *big.Int { return new(big.Int).Mul(x, y) }},
+		{"ExpMod", func(x, y, m *big.Int) *big.Int { return new(big.Int).Exp(x, y, m) }},
+	}
+	for _, bm := range moduli {
+		m := conv(bm)
+		for i, bx := range vals {
+			x := conv(bx)
+			for j, by := range vals {
+				y := conv(by)
+				for _, op := range modOps {
+					if op.name == "ExpMod" && (i+j)%4 != 0 {
+						continue // keep the test fast
+					}
+					want := new(big.Int)
+					if bm.Sign() != 0 {
+						want.Mod(op.big(bx, by, bm), bm)
+					}
+					got := toBig(reflect.New(typ).MethodByName(op.name).Call([]reflect.Value{x, y, m})[0])
+					if got.Cmp(want) != 0 {
+						t.Fatalf("%s(%x, %x, %x): got %x, want %x", op.name, bx, by, bm, got, want)
+					}
+				}
+			}
+		}
+	}
+	// Negative and oversized values wrap, and report overflow.
+	for _, b := range []*big.Int{big.NewInt(-1), mod, new(big.Int).Lsh(mod, 3)} {
+		v := reflect.New(typ)
+		if !v.MethodByName("SetFromBig").Call([]reflect.Value{reflect.ValueOf(b)})[0].Bool() {
+			t.Errorf("SetFromBig(%x): no overflow", b)
+		}
+		if got, want := toBig(v), new(big.Int).Mod(b, mod); got.Cmp(want) != 0 {
+			t.Errorf("SetFromBig(%x): got %x, want %x", b, got, want)
+		}
+	}
+}
+
+func TestUint160Address(t *testing.T) {
+	addr := []byte{
+		0xde, 0xad, 0xbe, 0xef, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55,
+		0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff,
+	}
+	a := new(Uint160).SetBytes(addr)
+	if got := a.String(); got != "0xdeadbeef00112233445566778899aabbccddeeff" {
+		t.Errorf("String: got %s", got)
+	}
+	if b := a.Bytes(); string(b[:]) != string(addr) {
+		t.Errorf("Bytes: got %x", b)
+	}
+}